@@ -1,17 +1,72 @@
 package bibleref
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/julianstephens/canonref/util"
 )
 
 // BibleRef represents a reference to a specific passage in the Bible, consisting
 // of an OSIS code for the book, a chapter number, and an optional verse or verse range.
+// EndChapter is set when the verse range ends in a later chapter, e.g. "Gen 1:30–2:3",
+// in which case Verse.EndVerse is a verse of EndChapter. Without a Verse, EndChapter makes a
+// range of whole chapters, e.g. "Gen 1–3". Additional holds the further verses
+// of a comma-separated list such as "Matt 5:3,5,7–9", in the order cited; Verse is the first.
 type BibleRef struct {
-	OSIS    string
-	Chapter int
-	Verse   *util.VerseRange
+	OSIS       string
+	Chapter    int
+	EndChapter *int
+	Verse      *util.VerseRange
+	Additional []util.VerseRange
+
+	// Meta holds caller-defined annotations, such as the ID of the source document or the
+	// offset the reference was found at, for carrying through a pipeline. It is not part of
+	// the reference's identity: Equal, Compare, formatting, and JSON encoding ignore it, and
+	// this package never sets it.
+	Meta map[string]string
+
+	// matchedAlias and typedBook record how the book was written when the reference was
+	// parsed with WithRetainedAlias; see MatchedAlias and FormatAsTyped.
+	matchedAlias string
+	typedBook    string
+}
+
+// MatchedAlias returns the normalized alias that resolved the book when the BibleRef was
+// parsed with WithRetainedAlias, e.g. "mt" for "Mt 5". It returns "" for references parsed
+// without the option or built directly.
+func (r BibleRef) MatchedAlias() string {
+	return r.matchedAlias
+}
+
+// NewChapterRef returns a chapter-only reference to the given chapter of the book with the
+// OSIS code osis, validated against the Table as Parse would. It returns a BibleRefError if
+// the book is unknown or the chapter is out of range.
+func NewChapterRef(osis string, chapter int, tbl *Table) (*BibleRef, error) {
+	return newRef(BibleRef{OSIS: osis, Chapter: chapter}, tbl)
+}
+
+// NewVerseRef returns a reference to a single verse, validated against the Table as Parse
+// would. It returns a BibleRefError if the book, chapter, or verse is invalid.
+func NewVerseRef(osis string, chapter, verse int, tbl *Table) (*BibleRef, error) {
+	return newRef(BibleRef{OSIS: osis, Chapter: chapter, Verse: &util.VerseRange{StartVerse: verse}}, tbl)
+}
+
+// NewRangeRef returns a reference to the verses start through end of one chapter, validated
+// against the Table as Parse would, so the result equals Parse of "Prov 3:5-8" for
+// NewRangeRef("Prov", 3, 5, 8, tbl). It returns a BibleRefError if the reference is invalid,
+// including when end is before start.
+func NewRangeRef(osis string, chapter, start, end int, tbl *Table) (*BibleRef, error) {
+	return newRef(BibleRef{OSIS: osis, Chapter: chapter, Verse: &util.VerseRange{StartVerse: start, EndVerse: &end}}, tbl)
+}
+
+// newRef validates ref against the Table and returns it.
+func newRef(ref BibleRef, tbl *Table) (*BibleRef, error) {
+	if err := ref.Validate(tbl); err != nil {
+		return nil, err
+	}
+	return &ref, nil
 }
 
 // String returns a string representation of the BibleRef in the format "OSIS Chapter:Verse"
@@ -19,119 +74,510 @@ type BibleRef struct {
 type Format int
 
 const (
-	FormatOSIS      Format = iota // "Prov.31.10-31"
-	FormatHuman                   // "Proverbs 31:10–31"
-	FormatCanonical               // "Prov 31:10-31"
+	FormatOSIS           Format = iota // "Prov.31.10-31"
+	FormatHuman                        // "Proverbs 31:10–31"
+	FormatCanonical                    // "Prov 31:10-31"
+	FormatHebrew                       // "משלי 31:10–31" in a right-to-left isolate
+	FormatHebrewNumerals               // "משלי ל״א:י׳–ל״א" in a right-to-left isolate
+	FormatCompact                      // "Prov31:10–31"
 )
 
 // String returns a string representation in the canonical format,
 // e.g. "Prov 3:16" or "Prov 3:16–18" or "Prov 3".
 func (r BibleRef) String() string {
-	if r.Verse == nil {
-		return fmt.Sprintf("%s %d", r.OSIS, r.Chapter)
+	return fmt.Sprintf("%s %s", r.OSIS, r.chapterVerse(":"))
+}
+
+// SafeString returns the canonical representation like String, or "" if r is nil.
+// It is intended for logging and display of optional references, such as the results of
+// ParseLines, without a nil check at every call site.
+func (r *BibleRef) SafeString() string {
+	if r == nil {
+		return ""
 	}
-	return fmt.Sprintf("%s %d:%s", r.OSIS, r.Chapter, r.Verse.String())
+	return r.String()
 }
 
 // Format returns a string representation of the BibleRef in the specified format.
 // For FormatOSIS, the format is "OSIS.Chapter.Verse" or "OSIS.Chapter" if Verse is nil.
 // For FormatHuman, the format is "BookName Chapter:Verse" or "BookName Chapter" if Verse is nil.
 // For FormatCanonical, the format is "OSIS Chapter:Verse" or "OSIS Chapter" if Verse is nil.
+// For FormatHebrew, the format is "HebrewName Chapter:Verse" wrapped in a right-to-left isolate,
+// using Name for books without a HebrewName; FormatHebrewNumerals also writes the numbers as
+// Hebrew numerals. For FormatCompact, the format is "OSISChapter:Verse" with no space after the
+// book, for dense display.
 func (r BibleRef) Format(f Format, tbl *Table) string {
 	switch f {
-	case FormatOSIS:
-		if r.Verse == nil {
-			return fmt.Sprintf("%s.%d", r.OSIS, r.Chapter)
-		}
-		return fmt.Sprintf("%s.%d.%s", r.OSIS, r.Chapter, r.Verse.String())
-	case FormatHuman:
-		book := tbl.ByOsis[r.OSIS]
-		if r.Verse == nil {
-			return fmt.Sprintf("%s %d", book.Name, r.Chapter)
-		}
-		return fmt.Sprintf("%s %d:%s", book.Name, r.Chapter, r.Verse.String())
-	case FormatCanonical:
-		if r.Verse == nil {
-			return fmt.Sprintf("%s %d", r.OSIS, r.Chapter)
+	case FormatHebrew:
+		return r.formatHebrew(tbl, false)
+	case FormatHebrewNumerals:
+		return r.formatHebrew(tbl, true)
+	}
+	if tmpl, ok := formatTemplates[f]; ok {
+		return r.FormatWith(tmpl, tbl)
+	}
+	return r.String()
+}
+
+// chapterVerse returns the chapter and verse portion of the BibleRef, using sep between
+// chapter and verse numbers, e.g. "3:16–18", "1:30–2:3" for a cross-chapter range, or "1–3" for
+// a chapter range.
+func (r BibleRef) chapterVerse(sep string) string {
+	if r.Verse == nil {
+		if r.EndChapter != nil {
+			return fmt.Sprintf("%d%s%d", r.Chapter, util.EnDash, *r.EndChapter)
 		}
-		return fmt.Sprintf("%s %d:%s", r.OSIS, r.Chapter, r.Verse.String())
-	default:
-		return r.String()
+		return strconv.Itoa(r.Chapter)
 	}
+	if r.EndChapter != nil && r.Verse.EndVerse != nil {
+		return fmt.Sprintf("%d%s%d%s%s%d%s%d%s", r.Chapter, sep, r.Verse.StartVerse, r.Verse.StartPart, util.EnDash, *r.EndChapter, sep, *r.Verse.EndVerse, r.Verse.EndPart)
+	}
+	res := fmt.Sprintf("%d%s%s", r.Chapter, sep, r.Verse.String())
+	for _, v := range r.Additional {
+		res += "," + v.String()
+	}
+	return res
 }
 
 // IsChapterOnly returns true if the BibleRef has only a chapter (i.e. it does not have a Verse).
+// This includes chapter ranges such as "Gen 1–3".
 func (r BibleRef) IsChapterOnly() bool {
 	return r.Verse == nil
 }
 
 // IsSingleVerse returns true if the BibleRef has a single verse
-// (i.e. it has a Verse and that Verse does not have an EndVerse).
+// (i.e. it has a Verse, that Verse does not have an EndVerse or "f"/"ff" notation, and there
+// are no Additional verses).
 func (r BibleRef) IsSingleVerse() bool {
-	return r.Verse != nil && r.Verse.EndVerse == nil
+	return r.Verse != nil && r.Verse.EndVerse == nil && r.Verse.Following == "" && len(r.Additional) == 0
 }
 
 // IsRange returns true if the BibleRef has a verse range
-// (i.e. it has a Verse and that Verse has an EndVerse).
+// (i.e. it has a Verse and that Verse has an EndVerse). A verse with the "f" or "ff"
+// notation, as in "Rom 8:28ff", is a range even when the chapter's last verse is unknown and
+// EndVerse is nil.
 func (r BibleRef) IsRange() bool {
-	return r.Verse != nil && r.Verse.EndVerse != nil
+	return r.Verse != nil && (r.Verse.EndVerse != nil || r.Verse.Following != "")
+}
+
+// ChapterRef returns the chapter-only reference for the chapter r starts in, e.g. "Prov 3" for
+// "Prov 3:5–8". A cross-chapter range or chapter range yields its first chapter.
+func (r BibleRef) ChapterRef() BibleRef {
+	return BibleRef{OSIS: r.OSIS, Chapter: r.Chapter}
+}
+
+// Parent returns the reference one level coarser than r, for breadcrumb navigation: the
+// chapter-only form of a verse reference (see ChapterRef), or nil for a chapter-only reference,
+// whose parent is the book identified by OSIS.
+func (r BibleRef) Parent() *BibleRef {
+	if r.Verse == nil {
+		return nil
+	}
+	parent := r.ChapterRef()
+	return &parent
+}
+
+// VerseIndex returns the zero-based index of the start verse within its chapter, for indexing
+// into a slice of verse texts. It returns false for chapter-only references.
+func (r BibleRef) VerseIndex() (int, bool) {
+	if r.Verse == nil {
+		return 0, false
+	}
+	return r.Verse.StartVerse - 1, true
 }
 
 // Validate checks if the BibleRef is valid according to the provided Table.
 // It checks if the OSIS code exists in the Table, if the chapter number is valid for the book,
-// and if the verse numbers are valid (positive integers and end verse is greater than or equal to start verse).
+// and if the verse numbers are valid (positive integers, or zero for a Book with AllowVerseZero,
+// and end verse is greater than or equal to start verse).
+// Verse parts, as in "Rom 3:23a", must be a single letter from "a" to "d".
+// For a cross-chapter range or chapter range, EndChapter must follow Chapter within the book, and
+// when the book has VerseCounts each endpoint is checked against the verse count of its own chapter.
 func (r BibleRef) Validate(tbl *Table) error {
-	book, ok := tbl.ByOsis[r.OSIS]
-	if !ok {
+	book, v := r.check(tbl)
+	switch v {
+	case invalidBook:
 		return &BibleRefError{
 			Kind:    KindUnknownBook,
 			Err:     ErrInvalidOSISCode,
 			Message: util.Ptr(fmt.Sprintf("unknown OSIS code: %s", r.OSIS)),
 		}
-	}
-
-	if r.Chapter < 1 || r.Chapter > book.Chapters {
+	case invalidChapter:
 		return &BibleRefError{
 			Kind:    KindInvalidChapter,
 			Err:     ErrInvalidChapter,
 			Message: util.Ptr(fmt.Sprintf("invalid chapter number %d for book %s", r.Chapter, book.Name)),
 		}
-	}
-
-	if r.Verse != nil {
-		if r.Verse.StartVerse < 1 {
+	case invalidEndChapter:
+		return &BibleRefError{
+			Kind:    KindInvalidChapter,
+			Err:     ErrInvalidChapter,
+			Message: util.Ptr(fmt.Sprintf("invalid end chapter %d for %s %d", *r.EndChapter, book.Name, r.Chapter)),
+		}
+	case invalidStartVerse:
+		return &BibleRefError{
+			Kind:    KindInvalidVerse,
+			Err:     ErrInvalidVerse,
+			Message: util.Ptr(fmt.Sprintf("start verse must be at least %d, got %d", book.firstVerse(), r.Verse.StartVerse)),
+		}
+	case invalidEndVerse:
+		if r.EndChapter != nil && r.Verse.EndVerse == nil {
 			return &BibleRefError{
 				Kind:    KindInvalidVerse,
 				Err:     ErrInvalidVerse,
-				Message: util.Ptr(fmt.Sprintf("start verse must be a positive integer, got %d", r.Verse.StartVerse)),
+				Message: util.Ptr(fmt.Sprintf("cross-chapter range ending in chapter %d must have an end verse", *r.EndChapter)),
 			}
 		}
-		if r.Verse.EndVerse != nil {
-			if *r.Verse.EndVerse < r.Verse.StartVerse {
+		return &BibleRefError{
+			Kind:    KindInvalidVerse,
+			Err:     ErrInvalidVerse,
+			Message: util.Ptr(fmt.Sprintf("end verse must be greater than or equal to start verse, got start: %d, end: %d", r.Verse.StartVerse, *r.Verse.EndVerse)),
+		}
+	case startVerseOutOfRange:
+		count, _ := book.VersesIn(r.Chapter)
+		return &BibleRefError{
+			Kind:    KindInvalidVerse,
+			Err:     ErrInvalidVerse,
+			Message: util.Ptr(fmt.Sprintf("verse %d out of range for %s %d, which has %d verses", r.Verse.StartVerse, book.Name, r.Chapter, count)),
+		}
+	case endVerseOutOfRange:
+		endChapter := r.endChapter()
+		count, _ := book.VersesIn(endChapter)
+		return &BibleRefError{
+			Kind:    KindInvalidVerse,
+			Err:     ErrInvalidVerse,
+			Message: util.Ptr(fmt.Sprintf("verse %d out of range for %s %d, which has %d verses", *r.Verse.EndVerse, book.Name, endChapter, count)),
+		}
+	case invalidAdditionalVerse:
+		if r.Verse == nil || r.EndChapter != nil {
+			return &BibleRefError{
+				Kind:    KindInvalidVerse,
+				Err:     ErrInvalidVerse,
+				Message: util.Ptr("a verse list must start with a verse and cannot follow a cross-chapter range"),
+			}
+		}
+		for _, v := range r.Additional {
+			if !segmentValid(book, r.Chapter, v) {
 				return &BibleRefError{
 					Kind:    KindInvalidVerse,
 					Err:     ErrInvalidVerse,
-					Message: util.Ptr(fmt.Sprintf("end verse must be greater than or equal to start verse, got start: %d, end: %d", r.Verse.StartVerse, *r.Verse.EndVerse)),
+					Message: util.Ptr(fmt.Sprintf("invalid verse %s in verse list for %s %d", v.String(), book.Name, r.Chapter)),
 				}
 			}
 		}
+	case invalidVersePart:
+		return &BibleRefError{
+			Kind:    KindInvalidVerse,
+			Err:     ErrInvalidVerse,
+			Message: util.Ptr(fmt.Sprintf("invalid verse part or following notation in %s", r.chapterVerse(":"))),
+		}
 	}
 
 	return nil
 }
 
+// IsValid reports whether the BibleRef is valid according to the provided Table.
+// It performs the same checks as Validate without allocating an error, for hot paths that
+// only need a yes/no answer.
+func (r BibleRef) IsValid(tbl *Table) bool {
+	_, v := r.check(tbl)
+	return v == valid
+}
+
+// validity is the outcome of the checks shared by Validate and IsValid.
+type validity int
+
+const (
+	valid validity = iota
+	invalidBook
+	invalidChapter
+	invalidEndChapter
+	invalidStartVerse
+	invalidEndVerse
+	startVerseOutOfRange
+	endVerseOutOfRange
+	invalidAdditionalVerse
+	invalidVersePart
+)
+
+// check runs the validation rules for the BibleRef and returns the first failure found,
+// along with the Book when it could be resolved.
+func (r BibleRef) check(tbl *Table) (Book, validity) {
+	book, ok := tbl.Book(r.OSIS)
+	if !ok {
+		return book, invalidBook
+	}
+
+	if r.Chapter < 1 || r.Chapter > book.Chapters {
+		return book, invalidChapter
+	}
+
+	if r.EndChapter != nil && (*r.EndChapter <= r.Chapter || *r.EndChapter > book.Chapters) {
+		return book, invalidEndChapter
+	}
+
+	if r.Verse != nil {
+		if r.Verse.StartVerse < book.firstVerse() {
+			return book, invalidStartVerse
+		}
+		if r.EndChapter != nil {
+			if r.Verse.EndVerse == nil || *r.Verse.EndVerse < book.firstVerse() {
+				return book, invalidEndVerse
+			}
+		} else if r.Verse.EndVerse != nil && *r.Verse.EndVerse < r.Verse.StartVerse {
+			return book, invalidEndVerse
+		}
+		if count, ok := book.VersesIn(r.Chapter); ok && r.Verse.StartVerse > count {
+			return book, startVerseOutOfRange
+		}
+		if r.Verse.EndVerse != nil {
+			if count, ok := book.VersesIn(r.endChapter()); ok && *r.Verse.EndVerse > count {
+				return book, endVerseOutOfRange
+			}
+		}
+		if !versePartsValid(*r.Verse, r.EndChapter == nil) {
+			return book, invalidVersePart
+		}
+	}
+
+	if len(r.Additional) > 0 {
+		if r.Verse == nil || r.EndChapter != nil {
+			return book, invalidAdditionalVerse
+		}
+		for _, v := range r.Additional {
+			if !segmentValid(book, r.Chapter, v) {
+				return book, invalidAdditionalVerse
+			}
+			if !versePartsValid(v, true) {
+				return book, invalidVersePart
+			}
+		}
+	}
+
+	return book, valid
+}
+
+// versePartsValid reports whether the verse parts of v are each a letter from "a" to "d",
+// with EndPart only on a range, and whether its Following notation is valid. When sameChapter
+// is set, a range within one verse must not end in an earlier part than it starts in, as in
+// "5b–5a".
+func versePartsValid(v util.VerseRange, sameChapter bool) bool {
+	if v.Following != "" && (!sameChapter || v.Validate() != nil) {
+		return false
+	}
+	if v.StartPart != "" && !util.IsVersePart(v.StartPart) {
+		return false
+	}
+	if v.EndPart != "" && (v.EndVerse == nil || !util.IsVersePart(v.EndPart)) {
+		return false
+	}
+	return !sameChapter || v.EndVerse == nil || *v.EndVerse != v.StartVerse || v.StartPart == "" || v.EndPart == "" || v.StartPart <= v.EndPart
+}
+
+// segmentValid reports whether v is a valid verse or verse range of the given chapter,
+// checking it against the chapter's verse count when the book has one.
+func segmentValid(book Book, chapter int, v util.VerseRange) bool {
+	start, end := segmentSpan(v)
+	if v.IsOpenEnded() {
+		end = start
+	}
+	if start < book.firstVerse() || end < start {
+		return false
+	}
+	count, ok := book.VersesIn(chapter)
+	return !ok || end <= count
+}
+
+// segmentSpan returns the inclusive start and end verses of a verse or verse range. An
+// open-ended "ff" range ends at unknownChapterSize, the unknown last verse of its chapter.
+func segmentSpan(v util.VerseRange) (int, int) {
+	if v.IsOpenEnded() {
+		return v.StartVerse, unknownChapterSize
+	}
+	if v.EndVerse == nil {
+		return v.StartVerse, v.StartVerse
+	}
+	return v.StartVerse, *v.EndVerse
+}
+
+// endChapter returns the chapter the BibleRef ends in, which is Chapter unless EndChapter is set.
+func (r BibleRef) endChapter() int {
+	if r.EndChapter != nil {
+		return *r.EndChapter
+	}
+	return r.Chapter
+}
+
+// VerseCount returns the number of verses covered by the BibleRef.
+// Single verses, verse ranges, and verse lists are counted directly, adding up each segment
+// as cited. Chapter-only references and chapter ranges need the book's VerseCounts, as do
+// cross-chapter ranges and "ff" ranges whose last verse was not filled in by Parse, and an
+// error is returned when the book is unknown or has no verse data.
+func (r BibleRef) VerseCount(tbl *Table) (int, error) {
+	if r.EndChapter != nil && r.Verse != nil && r.Verse.EndVerse != nil {
+		return r.crossChapterVerseCount(tbl)
+	}
+	if r.Verse != nil {
+		count := 0
+		for _, v := range append([]util.VerseRange{*r.Verse}, r.Additional...) {
+			n, ok := v.Count()
+			if !ok {
+				return 0, &BibleRefError{
+					Kind:    KindMissingData,
+					Err:     ErrVerseCountsUnavailable,
+					Message: util.Ptr(fmt.Sprintf("no verse count for %s %d", r.OSIS, r.Chapter)),
+				}
+			}
+			count += n
+		}
+		return count, nil
+	}
+
+	book, err := r.book(tbl)
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for ch := r.Chapter; ch <= r.endChapter(); ch++ {
+		count, ok := book.VersesIn(ch)
+		if !ok {
+			return 0, &BibleRefError{
+				Kind:    KindMissingData,
+				Err:     ErrVerseCountsUnavailable,
+				Message: util.Ptr(fmt.Sprintf("no verse count for %s %d", r.OSIS, ch)),
+			}
+		}
+		total += count
+	}
+
+	return total, nil
+}
+
+// crossChapterVerseCount counts the verses from the start verse to the end of Chapter, through
+// every chapter in between, and up to the end verse of EndChapter.
+func (r BibleRef) crossChapterVerseCount(tbl *Table) (int, error) {
+	book, err := r.book(tbl)
+	if err != nil {
+		return 0, err
+	}
+
+	total := *r.Verse.EndVerse - r.Verse.StartVerse + 1
+	for ch := r.Chapter; ch < *r.EndChapter; ch++ {
+		count, ok := book.VersesIn(ch)
+		if !ok {
+			return 0, &BibleRefError{
+				Kind:    KindMissingData,
+				Err:     ErrVerseCountsUnavailable,
+				Message: util.Ptr(fmt.Sprintf("no verse count for %s %d", r.OSIS, ch)),
+			}
+		}
+		total += count
+	}
+
+	return total, nil
+}
+
+// swapMargin is how close to the last verse of its chapter a verse must be for LooksSwapped
+// to consider it suspicious.
+const swapMargin = 2
+
+// LooksSwapped reports whether the chapter and verse of a single-verse reference may have been
+// typed the wrong way round, e.g. "Prov 10:31" for Prov 31:10. It is an advisory check for
+// "did you mean" hints: it returns true when swapping the numbers yields a valid reference and
+// the current verse is out of range or within the last few verses of its chapter. It needs the
+// book's VerseCounts and returns false when they are unavailable.
+func (r BibleRef) LooksSwapped(tbl *Table) bool {
+	if !r.IsSingleVerse() || r.EndChapter != nil {
+		return false
+	}
+
+	book, ok := tbl.Book(r.OSIS)
+	if !ok {
+		return false
+	}
+
+	count, ok := book.VersesIn(r.Chapter)
+	if !ok {
+		return false
+	}
+	swapped := BibleRef{OSIS: r.OSIS, Chapter: r.Verse.StartVerse, Verse: &util.VerseRange{StartVerse: r.Chapter}}
+	if _, ok := book.VersesIn(swapped.Chapter); !ok || !swapped.IsValid(tbl) {
+		return false
+	}
+
+	return r.Verse.StartVerse >= count-swapMargin
+}
+
+// BookJSON returns the JSON encoding of the Book the BibleRef belongs to.
+// It returns an error if the OSIS code is not in the Table.
+func (r BibleRef) BookJSON(tbl *Table) ([]byte, error) {
+	book, err := r.book(tbl)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(book)
+	if err != nil {
+		return nil, &BibleRefError{
+			Kind:    KindInvalidBook,
+			Err:     ErrInvalidBook,
+			Message: util.Ptr(fmt.Sprintf("failed to encode book %s", book.OSIS)),
+			Cause:   err,
+		}
+	}
+
+	return data, nil
+}
+
+// book returns the Book for the BibleRef's OSIS code, or a BibleRefError if it is unknown.
+func (r BibleRef) book(tbl *Table) (Book, error) {
+	book, ok := tbl.Book(r.OSIS)
+	if !ok {
+		return Book{}, &BibleRefError{
+			Kind:    KindUnknownBook,
+			Err:     ErrInvalidOSISCode,
+			Message: util.Ptr(fmt.Sprintf("unknown OSIS code: %s", r.OSIS)),
+		}
+	}
+	return book, nil
+}
+
 // Book represents a book of the Bible, including its OSIS code,
 // name, aliases, testament, order, and number of chapters.
+// VerseCounts optionally holds the number of verses in each chapter, in chapter order.
+// HebrewName optionally holds the book's Hebrew name for FormatHebrew.
+// AllowVerseZero permits verse 0, which some traditions use to number the superscription of a
+// Psalm, as in "Ps 51:0"; by default verses start at 1.
 type Book struct {
-	OSIS      string   `json:"osis"`
-	Name      string   `json:"name"`
-	Aliases   []string `json:"aliases"`
-	Testament string   `json:"testament"`
-	Order     int      `json:"order"`
-	Chapters  int      `json:"chapters"`
+	OSIS           string    `json:"osis"`
+	Name           string    `json:"name"`
+	Aliases        []string  `json:"aliases"`
+	Testament      Testament `json:"testament"`
+	Order          int       `json:"order"`
+	Chapters       int       `json:"chapters"`
+	VerseCounts    []int     `json:"verse_counts,omitempty"`
+	HebrewName     string    `json:"hebrew_name,omitempty"`
+	AllowVerseZero bool      `json:"allow_verse_zero,omitempty"`
+}
+
+// firstVerse returns the lowest verse number the Book permits: 0 with AllowVerseZero, else 1.
+func (b Book) firstVerse() int {
+	return util.If(b.AllowVerseZero, 0, 1)
+}
+
+// VersesIn returns the number of verses in the given chapter of the Book.
+// It returns false if the chapter is out of range or the Book has no verse count for it.
+func (b Book) VersesIn(chapter int) (int, bool) {
+	if chapter < 1 || chapter > len(b.VerseCounts) {
+		return 0, false
+	}
+	return b.VerseCounts[chapter-1], true
 }
 
 // Validate checks if the Book has valid data and returns an error if any validation fails.
+// Testament must be a spelling of one of the Testament constants accepted by ParseTestament.
+// VerseCounts is optional, but when present it must hold a positive count for every chapter.
 func (b Book) Validate() error {
 	if b.OSIS == "" {
 		return &BibleRefError{
@@ -164,5 +610,30 @@ func (b Book) Validate() error {
 		}
 	}
 
+	if !b.Testament.Valid() {
+		return &BibleRefError{
+			Kind:    KindInvalidBook,
+			Err:     ErrInvalidBook,
+			Message: util.Ptr(fmt.Sprintf("book %s has unknown testament %q", b.OSIS, b.Testament)),
+		}
+	}
+
+	if len(b.VerseCounts) > 0 && len(b.VerseCounts) != b.Chapters {
+		return &BibleRefError{
+			Kind:    KindInvalidBook,
+			Err:     ErrInvalidBook,
+			Message: util.Ptr(fmt.Sprintf("book %s has %d chapters but %d verse counts", b.OSIS, b.Chapters, len(b.VerseCounts))),
+		}
+	}
+	for i, count := range b.VerseCounts {
+		if count < 1 {
+			return &BibleRefError{
+				Kind:    KindInvalidBook,
+				Err:     ErrInvalidBook,
+				Message: util.Ptr(fmt.Sprintf("book %s chapter %d must have at least one verse", b.OSIS, i+1)),
+			}
+		}
+	}
+
 	return nil
 }
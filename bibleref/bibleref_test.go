@@ -1,6 +1,11 @@
 package bibleref_test
 
 import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/julianstephens/canonref/bibleref"
@@ -10,6 +15,22 @@ import (
 // testBooks creates test data with a minimal set of Bible books including canonical scriptures and apocrypha.
 func testBooks() []bibleref.Book {
 	return []bibleref.Book{
+		{
+			OSIS:      "Gen",
+			Name:      "Genesis",
+			Aliases:   []string{"genesis", "gen", "gn"},
+			Testament: "OT",
+			Order:     1,
+			Chapters:  50,
+		},
+		{
+			OSIS:      "Ps",
+			Name:      "Psalms",
+			Aliases:   []string{"psalms", "psalm", "ps", "psa"},
+			Testament: "OT",
+			Order:     19,
+			Chapters:  150,
+		},
 		{
 			OSIS:      "Prov",
 			Name:      "Proverbs",
@@ -50,6 +71,14 @@ func testBooks() []bibleref.Book {
 			Order:     40,
 			Chapters:  28,
 		},
+		{
+			OSIS:      "Jude",
+			Name:      "Jude",
+			Aliases:   []string{"jude", "jud"},
+			Testament: "NT",
+			Order:     65,
+			Chapters:  1,
+		},
 	}
 }
 
@@ -105,14 +134,15 @@ func TestTable_AliasNormalization(t *testing.T) {
 	}
 }
 
-// TestTable_DuplicateAliases checks that NewTable detects and reports duplicate alias keys.
+// TestTable_DuplicateAliases checks that NewTable detects and reports duplicate alias keys,
+// resolving them to the book with the lowest Order regardless of input order.
 func TestTable_DuplicateAliases(t *testing.T) {
 	// Create books with duplicate aliases
 	booksWithDuplicates := []bibleref.Book{
 		{
 			OSIS:      "Prov",
 			Name:      "Proverbs",
-			Aliases:   []string{"proverbs", "prov"},
+			Aliases:   []string{"proverbs", "prov", "pr"},
 			Testament: "OT",
 			Order:     20,
 			Chapters:  31,
@@ -120,28 +150,244 @@ func TestTable_DuplicateAliases(t *testing.T) {
 		{
 			OSIS:      "Matt",
 			Name:      "Matthew",
-			Aliases:   []string{"matthew", "prov"}, // Duplicate alias!
+			Aliases:   []string{"matthew", "prov", "pr"}, // Duplicate aliases!
 			Testament: "NT",
 			Order:     40,
 			Chapters:  28,
 		},
 	}
+	reversed := []bibleref.Book{booksWithDuplicates[1], booksWithDuplicates[0]}
 
-	tbl, err := bibleref.NewTable(booksWithDuplicates)
-	if err == nil {
-		// NewTable silently overwrites duplicates - document as potential bug
-		t.Logf("WARNING: NewTable did not detect duplicate alias 'prov' in books. Silently used last one: %s", tbl.ByAlias["prov"])
-		// The duplicate key will be silently overwritten in the map
-		if tbl.ByAlias["prov"] != "Matt" {
-			t.Errorf("duplicate alias 'prov' was overwritten; last value should be 'Matt', got %q", tbl.ByAlias["prov"])
+	for name, books := range map[string][]bibleref.Book{"in order": booksWithDuplicates, "reversed": reversed} {
+		t.Run(name, func(t *testing.T) {
+			tbl, err := bibleref.NewTable(books)
+			if err != nil {
+				t.Fatalf("NewTable failed: %v", err)
+			}
+			// "prov" is Proverbs' own OSIS code and "pr" is resolved by lowest Order
+			for _, alias := range []string{"prov", "pr"} {
+				if tbl.ByAlias[alias] != "Prov" {
+					t.Errorf("expected ambiguous alias %q to resolve to 'Prov', got %q", alias, tbl.ByAlias[alias])
+				}
+			}
+
+			conflicts := tbl.AliasConflicts()
+			if len(conflicts) != 2 {
+				t.Fatalf("expected 2 alias conflicts, got %+v", conflicts)
+			}
+			for _, c := range conflicts {
+				if c.Winner != "Prov" || c.Loser != "Matt" {
+					t.Errorf("expected conflict on %q won by Prov over Matt, got %+v", c.Alias, c)
+				}
+			}
+		})
+	}
+}
+
+// TestTable_ShadowingAliases tests reporting aliases that are the leading words of another
+// book's longer name or alias.
+func TestTable_ShadowingAliases(t *testing.T) {
+	tbl, err := bibleref.NewTable([]bibleref.Book{
+		{OSIS: "Wis", Name: "Wisdom of Solomon", Aliases: []string{"wisdom of solomon", "wis"}, Testament: "AP", Order: 70, Chapters: 19},
+		{OSIS: "Sir", Name: "Sirach", Aliases: []string{"sirach", "wisdom", "ecclesiasticus"}, Testament: "AP", Order: 71, Chapters: 51},
+		{OSIS: "Song", Name: "Song of Songs", Aliases: []string{"song", "song of solomon"}, Testament: "OT", Order: 22, Chapters: 8},
+		{OSIS: "1John", Name: "1 John", Aliases: []string{"1 john"}, Testament: "NT", Order: 62, Chapters: 5},
+		{OSIS: "Mark", Name: "Mark", Aliases: []string{"1"}, Testament: "NT", Order: 41, Chapters: 16},
+	})
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	// "song" prefixes only its own book's names; "1" shadows "1 john"
+	expected := []string{"1", "wisdom"}
+	if got := tbl.ShadowingAliases(); !slices.Equal(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	clean, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+	if got := clean.ShadowingAliases(); got != nil {
+		t.Errorf("expected no shadowing aliases, got %v", got)
+	}
+}
+
+// TestLoadTableFromJSON_VerseCounts tests loading verse counts from JSON and checking verses
+// against them, and that books without verse counts skip the check.
+func TestLoadTableFromJSON_VerseCounts(t *testing.T) {
+	data := []byte(`{
+		"schema": 1,
+		"work": "test",
+		"books": [
+			{"osis": "Jude", "name": "Jude", "aliases": ["jude"], "testament": "NT", "order": 65, "chapters": 1, "verse_counts": [25]},
+			{"osis": "Ruth", "name": "Ruth", "aliases": ["ruth"], "testament": "OT", "order": 8, "chapters": 4, "verse_counts": [22, 23, 18, 22]},
+			{"osis": "Gen", "name": "Genesis", "aliases": ["genesis"], "testament": "OT", "order": 1, "chapters": 50}
+		]
+	}`)
+	tbl, err := bibleref.LoadTableFromJSON(data)
+	if err != nil {
+		t.Fatalf("LoadTableFromJSON failed: %v", err)
+	}
+
+	testCases := []struct {
+		input string
+		valid bool
+	}{
+		{"Jude 1:25", true},
+		{"Jude 1:26", false},
+		{"Ruth 3:18", true},
+		{"Ruth 3:19", false},
+		{"Ruth 3:10-20", false},
+		{"Gen 1:999", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			_, err := bibleref.Parse(tc.input, tbl)
+			if tc.valid && err != nil {
+				t.Errorf("Parse(%q) failed: %v", tc.input, err)
+			}
+			if !tc.valid && !errors.Is(err, bibleref.ErrInvalidVerse) {
+				t.Errorf("expected ErrInvalidVerse for %q, got %v", tc.input, err)
+			}
+		})
+	}
+
+	invalid := []struct {
+		counts []int
+		desc   string
+	}{
+		{[]int{22, 23, 18}, "too few counts"},
+		{[]int{22, 23, 18, 22, 5}, "too many counts"},
+		{[]int{22, 0, 18, 22}, "empty chapter"},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.desc, func(t *testing.T) {
+			book := bibleref.Book{OSIS: "Ruth", Name: "Ruth", Testament: "OT", Order: 8, Chapters: 4, VerseCounts: tc.counts}
+			if err := book.Validate(); !errors.Is(err, bibleref.ErrInvalidBook) {
+				t.Errorf("expected ErrInvalidBook, got %v", err)
+			}
+		})
+	}
+}
+
+// TestTable_BooksInOrder tests enumerating books in canonical order and by testament.
+func TestTable_BooksInOrder(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	osis := func(books []bibleref.Book) []string {
+		codes := make([]string, len(books))
+		for i, book := range books {
+			codes[i] = book.OSIS
+		}
+		return codes
+	}
+
+	expected := []string{"Gen", "1Sam", "2Sam", "Ps", "Prov", "Matt", "Jude", "Wis"}
+	for range 3 {
+		if got := osis(tbl.BooksInOrder()); !slices.Equal(got, expected) {
+			t.Fatalf("expected %v, got %v", expected, got)
 		}
 	}
+
+	testCases := []struct {
+		testament bibleref.Testament
+		expected  []string
+	}{
+		{"OT", []string{"Gen", "1Sam", "2Sam", "Ps", "Prov"}},
+		{"NT", []string{"Matt", "Jude"}},
+		{"Apocrypha", []string{"Wis"}},
+		{"AP", []string{"Wis"}},
+		{"nt", []string{"Matt", "Jude"}},
+		{"Other", nil},
+	}
+	for _, tc := range testCases {
+		t.Run(string(tc.testament), func(t *testing.T) {
+			if got := osis(tbl.BooksByTestament(tc.testament)); !slices.Equal(got, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+
+	var nilTable *bibleref.Table
+	if nilTable.BooksInOrder() != nil || nilTable.BooksByTestament("OT") != nil {
+		t.Error("expected no books for a nil Table")
+	}
+}
+
+// TestTable_BookByOrder tests looking up books by their canonical order number.
+func TestTable_BookByOrder(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	book, ok := tbl.BookByOrder(20)
+	if !ok || book.OSIS != "Prov" {
+		t.Errorf("expected book 20 to be Prov, got %q (found: %v)", book.OSIS, ok)
+	}
+	if _, ok := tbl.BookByOrder(99); ok {
+		t.Error("expected no book with order 99")
+	}
+
+	literal := &bibleref.Table{ByOsis: map[string]bibleref.Book{"Matt": {OSIS: "Matt", Order: 40}}}
+	if book, ok := literal.BookByOrder(40); !ok || book.OSIS != "Matt" {
+		t.Errorf("expected lookup on a literal Table to find Matt, got %q (found: %v)", book.OSIS, ok)
+	}
+}
+
+// TestTable_Lookup tests resolving book names and aliases and looking up books by OSIS code.
+func TestTable_Lookup(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"Proverbs", "Prov"},
+		{"prov.", "Prov"},
+		{"  GENESIS ", "Gen"},
+		{"gn", "Gen"},
+		{"II Sam", "2Sam"},
+		{"1 Sam", "1Sam"},
+		{"matt", "Matt"},
+		{"Nope", ""},
+		{"", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			book, ok := tbl.Lookup(tc.input)
+			if ok != (tc.expected != "") || book.OSIS != tc.expected {
+				t.Errorf("Lookup(%q) = %q (found: %v), expected %q", tc.input, book.OSIS, ok, tc.expected)
+			}
+		})
+	}
+
+	if book, ok := tbl.Book("Prov"); !ok || book.Name != "Proverbs" {
+		t.Errorf("expected Book(\"Prov\") to be Proverbs, got %q (found: %v)", book.Name, ok)
+	}
+	if _, ok := tbl.Book("prov"); ok {
+		t.Error("expected Book to require an exact OSIS code")
+	}
+
+	var nilTable *bibleref.Table
+	if _, ok := nilTable.Lookup("Prov"); ok {
+		t.Error("expected no lookup on a nil Table")
+	}
+	if _, ok := nilTable.Book("Prov"); ok {
+		t.Error("expected no book on a nil Table")
+	}
 }
 
 // TestParse_ValidReferences tests parsing of valid Bible references.
-// NOTE: BUG EXPOSED - Book names starting with digits (e.g., "1 Samuel", "1 John") are not supported.
-// The parser splits on the first digit, which fails for books that start with a digit.
-// Only books that have at least one letter before the first digit can be parsed.
 func TestParse_ValidReferences(t *testing.T) {
 	books := testBooks()
 	tbl, err := bibleref.NewTable(books)
@@ -283,8 +529,13 @@ func TestParse_InvalidReferences(t *testing.T) {
 			expectError: true,
 		},
 		{
-			input:       "1Sam 15:1–16:1",
-			desc:        "cross-chapter range (unsupported)",
+			input:       "1Sam 15:1–14:1",
+			desc:        "cross-chapter range ending before it starts",
+			expectError: true,
+		},
+		{
+			input:       "Prov 31:10–32:1",
+			desc:        "cross-chapter range ending beyond max chapter",
 			expectError: true,
 		},
 	}
@@ -303,7 +554,6 @@ func TestParse_InvalidReferences(t *testing.T) {
 }
 
 // TestParseCanonical_Rendering tests that parsing and then calling String() yields canonical form.
-// NOTE: BUG EXPOSED - Book names starting with digits are not supported due to parser design.
 func TestParseCanonical_Rendering(t *testing.T) {
 	books := testBooks()
 	tbl, err := bibleref.NewTable(books)
@@ -376,6 +626,8 @@ func TestParseCanonical_NormalizationVariants(t *testing.T) {
 		"PRO 31:10-31",
 		"Pro 31:10–31",
 		"   Prov   31:10-31   ",
+		"Prov ３１:１０-３１",
+		"Prov ٣١:١٠-٣١",
 	}
 
 	expectedCanonical := "Prov 31:10–31"
@@ -404,3 +656,2154 @@ func TestParseCanonical_NormalizationVariants(t *testing.T) {
 		})
 	}
 }
+
+// TestParse_VerseQualifiers tests that "v." and "vv." verse markers resolve to a single verse
+// and a verse range respectively, and that chapter qualifiers are ignored. "Ch" after a
+// numeral is the Chronicles abbreviation and must be kept.
+func TestParse_VerseQualifiers(t *testing.T) {
+	books := append(testBooks(),
+		bibleref.Book{OSIS: "1Chr", Name: "1 Chronicles", Aliases: []string{"1 chronicles", "1 chr", "1 ch"}, Testament: "OT", Order: 13, Chapters: 29},
+		bibleref.Book{OSIS: "2Chr", Name: "2 Chronicles", Aliases: []string{"2 chronicles", "2 chr", "2 ch"}, Testament: "OT", Order: 14, Chapters: 36},
+	)
+	tbl, err := bibleref.NewTable(books)
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input     string
+		expected  string
+		wantRange bool
+		desc      string
+	}{
+		{"Prov 3 vv. 5-8", "Prov 3:5–8", true, "plural marker with range"},
+		{"Prov 3 v. 5", "Prov 3:5", false, "singular marker with single verse"},
+		{"Prov 3 vv 5-8", "Prov 3:5–8", true, "plural marker without period"},
+		{"Proverbs 3 verses 5–8", "Prov 3:5–8", true, "spelled-out plural marker"},
+		{"Prov ch. 3 v. 5", "Prov 3:5", false, "chapter and verse qualifiers"},
+		{"Prov Chap. 3 Vs. 5", "Prov 3:5", false, "older chapter and verse abbreviations"},
+		{"Proverbs chaps. 3 vss. 5-8", "Prov 3:5–8", true, "older plural abbreviations"},
+		{"Prov chap 3 vs 5", "Prov 3:5", false, "older abbreviations without periods"},
+		{"1 Ch 3:5", "1Chr 3:5", false, "Ch after a numeric prefix"},
+		{"I Ch 3", "1Chr 3", false, "Ch after a roman prefix"},
+		{"2 Ch 7:14", "2Chr 7:14", false, "Ch abbreviating 2 Chronicles"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ref, err := bibleref.Parse(tc.input, tbl)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tc.input, err)
+			}
+			if ref.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, ref.String())
+			}
+			if ref.IsRange() != tc.wantRange {
+				t.Errorf("expected IsRange() to be %v for %q", tc.wantRange, tc.input)
+			}
+		})
+	}
+}
+
+// TestParseDetailed_SingleChapterShorthand tests that a bare number after a single-chapter book
+// is read as a verse of chapter 1 and that the inference is reported.
+func TestParseDetailed_SingleChapterShorthand(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input     string
+		expected  string
+		shorthand bool
+		desc      string
+	}{
+		{"Jude 4", "Jude 1:4", true, "verse shorthand"},
+		{"Jude 1:4", "Jude 1:4", false, "explicit chapter and verse"},
+		{"Jude 1", "Jude 1", false, "chapter-only whole book"},
+		{"Prov 4", "Prov 4", false, "multi-chapter book is unaffected"},
+		{"Jude 3-5", "Jude 1:3–5", true, "dashed verse range shorthand"},
+		{"Jude 1-3", "Jude 1:1–3", true, "dashed range from the first verse"},
+		{"Gen 3-5", "Gen 3–5", false, "multi-chapter book keeps the chapter range"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			info, err := bibleref.ParseDetailed(tc.input, tbl)
+			if err != nil {
+				t.Fatalf("ParseDetailed(%q) failed: %v", tc.input, err)
+			}
+			if info.Ref.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, info.Ref.String())
+			}
+			if info.SingleChapterShorthand != tc.shorthand {
+				t.Errorf("expected SingleChapterShorthand %v, got %v", tc.shorthand, info.SingleChapterShorthand)
+			}
+		})
+	}
+}
+
+// TestParse_StrayBookToken tests that a second book token after the chapter/verse is rejected
+// with ErrUnexpectedBookToken rather than a generic unknown-book error.
+func TestParse_StrayBookToken(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	inputs := []string{
+		"Prov 31:10-Matt 1",
+		"Prov 31:10–Matt 1:1",
+		"Prov 3:5 Matt 1",
+		"Prov 3 Matt 1:1",
+	}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			_, err := bibleref.Parse(input, tbl)
+			var refErr *bibleref.BibleRefError
+			if !errors.As(err, &refErr) {
+				t.Fatalf("expected BibleRefError, got %v", err)
+			}
+			if !errors.Is(refErr.Cause, bibleref.ErrUnexpectedBookToken) {
+				t.Errorf("expected cause ErrUnexpectedBookToken, got %v", refErr.Cause)
+			}
+		})
+	}
+
+	if _, err := bibleref.Parse("1 Samuel 3:1", tbl); err != nil {
+		t.Errorf("numeric book prefix should not be treated as a stray book: %v", err)
+	}
+
+	for _, input := range []string{"Prov 3 5", "Prov 3 5-6"} {
+		t.Run(input, func(t *testing.T) {
+			_, err := bibleref.Parse(input, tbl)
+			if !errors.Is(err, bibleref.ErrBibleRefParseFailed) {
+				t.Fatalf("expected ErrBibleRefParseFailed, got %v", err)
+			}
+			if errors.Is(err, bibleref.ErrUnexpectedBookToken) {
+				t.Errorf("numeric token should not be reported as a stray book: %v", err)
+			}
+		})
+	}
+}
+
+// TestParse_AttachedChapter tests that a chapter/verse written directly after the book name
+// without a space is split from the book, for both plain and digit-prefixed book names.
+func TestParse_AttachedChapter(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"Psalm119:105", "Ps 119:105"},
+		{"Genesis1:1", "Gen 1:1"},
+		{"1Samuel3:1", "1Sam 3:1"},
+		{"Prov31:10-31", "Prov 31:10–31"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			ref, err := bibleref.Parse(tc.input, tbl)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tc.input, err)
+			}
+			if ref.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, ref.String())
+			}
+		})
+	}
+}
+
+// TestParse_DoubledColon tests that repeated chapter-verse colons are collapsed into one.
+func TestParse_DoubledColon(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"Prov 3::5", "Prov 3:5"},
+		{"Prov 3:::5-6", "Prov 3:5–6"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			ref, err := bibleref.Parse(tc.input, tbl)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tc.input, err)
+			}
+			if ref.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, ref.String())
+			}
+		})
+	}
+
+	if _, err := bibleref.Parse("Prov 3:5:6", tbl); err == nil {
+		t.Error("expected separate colons to remain an error")
+	}
+}
+
+// TestParse_RelativeChapters tests that negative chapters resolve from the end of the book
+// only when WithRelativeChapters is given.
+func TestParse_RelativeChapters(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"Prov -1", "Prov 31"},
+		{"Prov -2", "Prov 30"},
+		{"Prov -1:10-31", "Prov 31:10–31"},
+		{"Prov -31", "Prov 1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			ref, err := bibleref.Parse(tc.input, tbl, bibleref.WithRelativeChapters())
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tc.input, err)
+			}
+			if ref.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, ref.String())
+			}
+		})
+	}
+
+	if _, err := bibleref.Parse("Prov -40", tbl, bibleref.WithRelativeChapters()); err == nil {
+		t.Error("expected out-of-range relative chapter to fail")
+	}
+	if _, err := bibleref.Parse("Prov -1", tbl); err == nil {
+		t.Error("expected relative chapter to fail without WithRelativeChapters")
+	}
+	for _, input := range []string{"Prov -1-3", "Prov -3-1", "Prov -2:5-31:1"} {
+		if _, err := bibleref.Parse(input, tbl, bibleref.WithRelativeChapters()); !errors.Is(err, bibleref.ErrInvalidChapter) {
+			t.Errorf("expected ErrInvalidChapter for relative range %q, got %v", input, err)
+		}
+	}
+
+	ruth, err := bibleref.NewTable([]bibleref.Book{{OSIS: "Ruth", Name: "Ruth", Testament: "OT", Order: 8, Chapters: 4, VerseCounts: []int{22, 23, 18, 22}}})
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+	ref, err := bibleref.Parse("Ruth -2:10ff", ruth, bibleref.WithRelativeChapters())
+	if err != nil {
+		t.Fatalf("Parse of relative chapter with ff failed: %v", err)
+	}
+	if ref.Chapter != 3 || ref.Verse.EndVerse == nil || *ref.Verse.EndVerse != 18 {
+		t.Errorf("expected ff to end at the last verse of Ruth 3, got %+v", ref.Verse)
+	}
+}
+
+// TestBibleRef_BookJSON tests that the metadata of a reference's book is encoded as JSON.
+func TestBibleRef_BookJSON(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	data, err := bibleref.MustParse("Prov 3:5", tbl).BookJSON(tbl)
+	if err != nil {
+		t.Fatalf("BookJSON failed: %v", err)
+	}
+
+	var book bibleref.Book
+	if err := json.Unmarshal(data, &book); err != nil {
+		t.Fatalf("failed to decode BookJSON output %s: %v", data, err)
+	}
+	if book.OSIS != "Prov" || book.Name != "Proverbs" || book.Testament != "OT" || book.Order != 20 || book.Chapters != 31 {
+		t.Errorf("unexpected book metadata: %s", data)
+	}
+
+	unknown := bibleref.BibleRef{OSIS: "Xyz", Chapter: 1}
+	if _, err := unknown.BookJSON(tbl); !errors.Is(err, bibleref.ErrInvalidOSISCode) {
+		t.Errorf("expected ErrInvalidOSISCode for unknown book, got %v", err)
+	}
+}
+
+// TestParse_LeadingArticle tests that a leading "the" is ignored when it is not part of an alias,
+// and that an alias beginning with "the" still takes precedence.
+func TestParse_LeadingArticle(t *testing.T) {
+	books := append(testBooks(), bibleref.Book{
+		OSIS:      "Rev",
+		Name:      "Revelation",
+		Aliases:   []string{"the revelation", "rev"},
+		Testament: "NT",
+		Order:     66,
+		Chapters:  22,
+	}, bibleref.Book{
+		OSIS:      "TheRev",
+		Name:      "The Revelation of Peter",
+		Aliases:   []string{"revelation"},
+		Testament: "Apocrypha",
+		Order:     90,
+		Chapters:  1,
+	})
+	tbl, err := bibleref.NewTable(books)
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected string
+		desc     string
+	}{
+		{"The Proverbs 3:5", "Prov 3:5", "article before a full name"},
+		{"The WISDOM of soloMon 2", "Wis 2", "article before a multi-word name"},
+		{"the   prov 3", "Prov 3", "article with extra spaces"},
+		{"The Revelation 1:1", "Rev 1:1", "alias beginning with the article wins"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ref, err := bibleref.Parse(tc.input, tbl)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tc.input, err)
+			}
+			if ref.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, ref.String())
+			}
+		})
+	}
+}
+
+// TestParseDetailed_UnknownBookPartial tests that the chapter and verse are still reported
+// when only the book could not be resolved.
+func TestParseDetailed_UnknownBookPartial(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	info, err := bibleref.ParseDetailed("Provrbs 3:5-7", tbl)
+	if err == nil {
+		t.Fatal("expected unknown book error")
+	}
+	if info == nil || info.Partial == nil {
+		t.Fatalf("expected partial result alongside unknown book error, got %+v", info)
+	}
+	if info.Partial.OSIS != "provrbs" || info.Partial.Chapter != 3 {
+		t.Errorf("expected partial provrbs 3, got %s %d", info.Partial.OSIS, info.Partial.Chapter)
+	}
+	if info.Partial.Verse == nil || info.Partial.Verse.String() != "5–7" {
+		t.Errorf("expected partial verse 5–7, got %v", info.Partial.Verse)
+	}
+	if info.RawChapterVerse != "3:5-7" {
+		t.Errorf("expected raw chapter/verse %q, got %q", "3:5-7", info.RawChapterVerse)
+	}
+
+	if info, err := bibleref.ParseDetailed("Provrbs 3:x", tbl); err == nil || info != nil {
+		t.Errorf("expected no partial result when the verse is also invalid, got %+v", info)
+	}
+
+	info, err = bibleref.ParseDetailed("Prov 3:5", tbl)
+	if err != nil {
+		t.Fatalf("ParseDetailed failed: %v", err)
+	}
+	if info.Partial != nil {
+		t.Errorf("expected no partial result for a resolved book, got %+v", info.Partial)
+	}
+}
+
+// TestParse_CaseInsensitiveOSISFallback tests that a lowercase OSIS code resolves even when
+// the Table has no alias entry for it.
+func TestParse_CaseInsensitiveOSISFallback(t *testing.T) {
+	sam := bibleref.Book{OSIS: "1Sam", Name: "1 Samuel", Testament: "OT", Order: 9, Chapters: 31}
+	tbl := &bibleref.Table{
+		ByOsis:  map[string]bibleref.Book{"1Sam": sam},
+		ByAlias: map[string]string{},
+	}
+
+	for _, input := range []string{"1sam 3:1", "1SAM 3:1", "1Sam 3:1"} {
+		t.Run(input, func(t *testing.T) {
+			ref, err := bibleref.Parse(input, tbl)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", input, err)
+			}
+			if ref.String() != "1Sam 3:1" {
+				t.Errorf("expected %q, got %q", "1Sam 3:1", ref.String())
+			}
+		})
+	}
+}
+
+// TestBibleRef_IsValid tests that IsValid agrees with Validate for valid and invalid references.
+func TestBibleRef_IsValid(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		ref      bibleref.BibleRef
+		expected bool
+		desc     string
+	}{
+		{bibleref.BibleRef{OSIS: "Prov", Chapter: 3, Verse: &util.VerseRange{StartVerse: 5}}, true, "single verse"},
+		{bibleref.BibleRef{OSIS: "Prov", Chapter: 31}, true, "chapter only"},
+		{bibleref.BibleRef{OSIS: "Xyz", Chapter: 1}, false, "unknown book"},
+		{bibleref.BibleRef{OSIS: "Prov", Chapter: 32}, false, "chapter out of range"},
+		{bibleref.BibleRef{OSIS: "Prov", Chapter: 3, Verse: &util.VerseRange{StartVerse: 0}}, false, "verse zero"},
+		{bibleref.BibleRef{OSIS: "Prov", Chapter: 3, Verse: &util.VerseRange{StartVerse: 8, EndVerse: util.Ptr(5)}}, false, "reversed range"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.ref.IsValid(tbl); got != tc.expected {
+				t.Errorf("expected IsValid() %v, got %v", tc.expected, got)
+			}
+			if (tc.ref.Validate(tbl) == nil) != tc.expected {
+				t.Errorf("IsValid and Validate disagree for %+v", tc.ref)
+			}
+		})
+	}
+}
+
+func BenchmarkBibleRef_Validate(b *testing.B) {
+	tbl, _ := bibleref.NewTable(testBooks())
+	ref := bibleref.BibleRef{OSIS: "Prov", Chapter: 32}
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = ref.Validate(tbl)
+	}
+}
+
+func BenchmarkBibleRef_IsValid(b *testing.B) {
+	tbl, _ := bibleref.NewTable(testBooks())
+	ref := bibleref.BibleRef{OSIS: "Prov", Chapter: 32}
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = ref.IsValid(tbl)
+	}
+}
+
+// TestParse_CommaSeparator tests that a comma is inferred as the chapter-verse separator only
+// when no colon is present.
+func TestParse_CommaSeparator(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"Prov 3:5", "Prov 3:5"},
+		{"Prov 3,5", "Prov 3:5"},
+		{"Prov 3,5-8", "Prov 3:5–8"},
+		{"Prov3,5", "Prov 3:5"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			ref, err := bibleref.Parse(tc.input, tbl)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tc.input, err)
+			}
+			if ref.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, ref.String())
+			}
+		})
+	}
+
+	// a colon takes precedence, so the comma is not reinterpreted as a chapter separator
+	if ref, err := bibleref.Parse("Prov 3:5,7", tbl); err == nil && ref.Chapter != 3 {
+		t.Errorf("expected colon to remain the chapter separator, got %v", ref)
+	}
+	if _, err := bibleref.Parse("Prov 3,5,7", tbl); err == nil {
+		t.Error("expected more than one comma without a colon to be rejected")
+	}
+}
+
+// TestParse_CrossChapterRange tests parsing and validation of verse ranges that end in a later
+// chapter, with each endpoint checked against the verse count of its own chapter.
+func TestParse_CrossChapterRange(t *testing.T) {
+	tbl, err := bibleref.NewTable([]bibleref.Book{
+		{
+			OSIS:        "Gen",
+			Name:        "Genesis",
+			Aliases:     []string{"genesis", "gen"},
+			Testament:   "OT",
+			Order:       1,
+			Chapters:    3,
+			VerseCounts: []int{31, 25, 24},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	validCases := []struct {
+		input      string
+		expected   string
+		endChapter int
+		count      int
+	}{
+		{"Gen 1:30-2:3", "Gen 1:30–2:3", 2, 5},
+		{"Gen 1:31–3:1", "Gen 1:31–3:1", 3, 27},
+		{"Gen 2:25-3:24", "Gen 2:25–3:24", 3, 25},
+	}
+
+	for _, tc := range validCases {
+		t.Run(tc.input, func(t *testing.T) {
+			ref, err := bibleref.Parse(tc.input, tbl)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tc.input, err)
+			}
+			if ref.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, ref.String())
+			}
+			if ref.EndChapter == nil || *ref.EndChapter != tc.endChapter {
+				t.Errorf("expected end chapter %d, got %v", tc.endChapter, ref.EndChapter)
+			}
+			count, err := ref.VerseCount(tbl)
+			if err != nil {
+				t.Fatalf("VerseCount failed: %v", err)
+			}
+			if count != tc.count {
+				t.Errorf("expected %d verses, got %d", tc.count, count)
+			}
+		})
+	}
+
+	invalidCases := []struct {
+		input string
+		desc  string
+	}{
+		{"Gen 1:32-2:3", "start verse beyond its chapter"},
+		{"Gen 1:30-2:26", "end verse beyond its chapter"},
+		{"Gen 2:1-1:5", "end chapter before start chapter"},
+		{"Gen 1:1-2:0", "end verse 0"},
+		{"Gen 1:5-1:2", "reversed range within one chapter"},
+	}
+
+	for _, tc := range invalidCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ref, err := bibleref.Parse(tc.input, tbl)
+			if err == nil {
+				t.Fatalf("Parse(%q) expected error but got success: %v", tc.input, ref)
+			}
+			var refErr *bibleref.BibleRefError
+			if !errors.As(err, &refErr) {
+				t.Fatalf("expected BibleRefError, got %T", err)
+			}
+		})
+	}
+
+	// a range that closes in its starting chapter is an ordinary verse range
+	ref, err := bibleref.Parse("Gen 1:3-1:5", tbl)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if ref.EndChapter != nil || ref.String() != "Gen 1:3–5" {
+		t.Errorf("expected same-chapter range Gen 1:3–5, got %v", ref)
+	}
+
+	// per-chapter counts also bound ordinary references
+	if _, err := bibleref.Parse("Gen 2:26", tbl); err == nil {
+		t.Error("expected verse beyond chapter verse count to be rejected")
+	}
+}
+
+// TestParse_DefaultBook tests that a reference without a book token resolves against the only
+// book of a single-book Table or against the book set with WithDefaultBook.
+func TestParse_DefaultBook(t *testing.T) {
+	single, err := bibleref.NewTable([]bibleref.Book{
+		{OSIS: "Prov", Name: "Proverbs", Aliases: []string{"proverbs", "prov"}, Testament: "OT", Order: 20, Chapters: 31},
+	})
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		tbl      *bibleref.Table
+		opts     []bibleref.ParseOption
+		expected string
+		desc     string
+	}{
+		{"3:5", single, nil, "Prov 3:5", "single-book table verse"},
+		{"3", single, nil, "Prov 3", "single-book table chapter"},
+		{"Prov 3:5", single, nil, "Prov 3:5", "explicit book still accepted"},
+		{"1:1-3", tbl, []bibleref.ParseOption{bibleref.WithDefaultBook("Matt")}, "Matt 1:1–3", "configured default book"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ref, err := bibleref.Parse(tc.input, tc.tbl, tc.opts...)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tc.input, err)
+			}
+			if ref.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, ref.String())
+			}
+		})
+	}
+
+	// without a default, a multi-book table still requires a book
+	if ref, err := bibleref.Parse("3:5", tbl); err == nil {
+		t.Errorf("expected missing book to be rejected, got %v", ref)
+	}
+}
+
+// TestParseInfo_FormatPreservingInput tests that the book is echoed as typed while the
+// chapter and verse are normalized.
+func TestParseInfo_FormatPreservingInput(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"PROVERBS 3:5", "PROVERBS 3:5"},
+		{"  proverbs   3:5-8 ", "proverbs 3:5–8"},
+		{"1 Samuel 3", "1 Samuel 3"},
+		{"Psalm119:105", "Psalm 119:105"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			info, err := bibleref.ParseDetailed(tc.input, tbl)
+			if err != nil {
+				t.Fatalf("ParseDetailed(%q) failed: %v", tc.input, err)
+			}
+			if got := info.FormatPreservingInput(); got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+// TestParseInfo_FormatPreservingInput_Zeros tests round-tripping zero-padded chapter and verse
+// numbers with WithPreserveInputZeros.
+func TestParseInfo_FormatPreservingInput_Zeros(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input     string
+		preserved string
+		plain     string
+	}{
+		{"Prov 03:05", "Prov 03:05", "Prov 3:5"},
+		{"Prov 03:05-08", "Prov 03:05–08", "Prov 3:5–8"},
+		{"Prov 3:005,07", "Prov 3:005,07", "Prov 3:5,7"},
+		{"Gen 01:30-02:03", "Gen 01:30–02:03", "Gen 1:30–2:3"},
+		{"Prov 3:5", "Prov 3:5", "Prov 3:5"},
+		{"Jude 04", "Jude 1:4", "Jude 1:4"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			info, err := bibleref.ParseDetailed(tc.input, tbl)
+			if err != nil {
+				t.Fatalf("ParseDetailed(%q) failed: %v", tc.input, err)
+			}
+			if got := info.FormatPreservingInput(bibleref.WithPreserveInputZeros()); got != tc.preserved {
+				t.Errorf("expected %q with zeros preserved, got %q", tc.preserved, got)
+			}
+			if got := info.FormatPreservingInput(); got != tc.plain {
+				t.Errorf("expected %q, got %q", tc.plain, got)
+			}
+		})
+	}
+}
+
+// TestBibleRef_VerseIndex tests the zero-based start verse index for verses, ranges, and
+// chapter-only references.
+func TestBibleRef_VerseIndex(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input      string
+		expected   int
+		expectedOk bool
+	}{
+		{"Prov 3:1", 0, true},
+		{"Prov 3:5", 4, true},
+		{"Prov 3:5-8", 4, true},
+		{"Prov 3", 0, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			got, ok := bibleref.MustParse(tc.input, tbl).VerseIndex()
+			if ok != tc.expectedOk || got != tc.expected {
+				t.Errorf("expected (%d, %v), got (%d, %v)", tc.expected, tc.expectedOk, got, ok)
+			}
+		})
+	}
+}
+
+// TestBibleRef_LooksSwapped tests the swapped chapter and verse heuristic.
+func TestBibleRef_LooksSwapped(t *testing.T) {
+	tbl, err := bibleref.NewTable([]bibleref.Book{
+		{
+			OSIS:      "Prov",
+			Name:      "Proverbs",
+			Aliases:   []string{"proverbs", "prov"},
+			Testament: "OT",
+			Order:     20,
+			Chapters:  31,
+			VerseCounts: []int{
+				33, 22, 35, 27, 23, 35, 27, 36, 18, 32, 31, 28, 25, 35, 33, 33,
+				28, 24, 29, 30, 31, 29, 35, 34, 28, 28, 27, 28, 27, 33, 31,
+			},
+		},
+		{OSIS: "Gen", Name: "Genesis", Testament: "OT", Order: 1, Chapters: 50},
+	})
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		ref      bibleref.BibleRef
+		expected bool
+		desc     string
+	}{
+		{bibleref.BibleRef{OSIS: "Prov", Chapter: 10, Verse: &util.VerseRange{StartVerse: 31}}, true, "plausible swap near end of chapter"},
+		{bibleref.BibleRef{OSIS: "Prov", Chapter: 9, Verse: &util.VerseRange{StartVerse: 20}}, true, "verse beyond chapter with valid swap"},
+		{bibleref.BibleRef{OSIS: "Prov", Chapter: 3, Verse: &util.VerseRange{StartVerse: 5}}, false, "ordinary verse"},
+		{bibleref.BibleRef{OSIS: "Prov", Chapter: 3, Verse: &util.VerseRange{StartVerse: 35}}, false, "swap would give a missing chapter"},
+		{bibleref.BibleRef{OSIS: "Prov", Chapter: 3, Verse: &util.VerseRange{StartVerse: 5, EndVerse: util.Ptr(8)}}, false, "range"},
+		{bibleref.BibleRef{OSIS: "Gen", Chapter: 10, Verse: &util.VerseRange{StartVerse: 31}}, false, "no verse counts"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.ref.LooksSwapped(tbl); got != tc.expected {
+				t.Errorf("LooksSwapped(%v) = %v, expected %v", tc.ref, got, tc.expected)
+			}
+		})
+	}
+}
+
+// TestParse_CompactOSIS tests the compact machine form of a lowercased OSIS code with the
+// chapter and verse attached, e.g. "2sam3:1".
+func TestParse_CompactOSIS(t *testing.T) {
+	books := append(testBooks(), bibleref.Book{
+		OSIS:      "1Kgs",
+		Name:      "1 Kings",
+		Aliases:   []string{"1 kings"},
+		Testament: "OT",
+		Order:     11,
+		Chapters:  22,
+	})
+	tbl, err := bibleref.NewTable(books)
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"2sam3:1", "2Sam 3:1"},
+		{"1kgs2:3", "1Kgs 2:3"},
+		{"prov31:10-31", "Prov 31:10–31"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			ref, err := bibleref.Parse(tc.input, tbl)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tc.input, err)
+			}
+			if ref.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, ref.String())
+			}
+		})
+	}
+
+	_, err = bibleref.Parse("9xyz1:1", tbl)
+	if !errors.Is(err, bibleref.ErrBibleRefParseFailed) {
+		t.Fatalf("expected ErrBibleRefParseFailed for bogus book, got %v", err)
+	}
+	var refErr *bibleref.BibleRefError
+	if !errors.As(err, &refErr) || !errors.Is(refErr.Cause, bibleref.ErrInvalidOSISCode) {
+		t.Errorf("expected unknown book cause, got %v", err)
+	}
+}
+
+// TestParse_NumericBookPrefix tests book names that begin with a numeric or roman numeral
+// prefix, written with or without a space before the name.
+func TestParse_NumericBookPrefix(t *testing.T) {
+	books := append(testBooks(),
+		bibleref.Book{OSIS: "1Kgs", Name: "1 Kings", Aliases: []string{"1 kings"}, Testament: "OT", Order: 11, Chapters: 22},
+		bibleref.Book{OSIS: "1John", Name: "1 John", Aliases: []string{"1 john", "i john"}, Testament: "NT", Order: 62, Chapters: 5},
+		bibleref.Book{OSIS: "3John", Name: "3 John", Aliases: []string{"3 john", "iii john"}, Testament: "NT", Order: 64, Chapters: 1},
+		bibleref.Book{OSIS: "Isa", Name: "Isaiah", Aliases: []string{"isaiah", "isa"}, Testament: "OT", Order: 23, Chapters: 66},
+	)
+	tbl, err := bibleref.NewTable(books)
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"1John 3:16", "1John 3:16"},
+		{"1 John 3:16", "1John 3:16"},
+		{"IJohn 3:16", "1John 3:16"},
+		{"2Sam 24", "2Sam 24"},
+		{"IIIJohn 1", "3John 1"},
+		{"III John 1", "3John 1"},
+		{"1Kings 2:3", "1Kgs 2:3"},
+		{"2 samuel 24:1", "2Sam 24:1"},
+		{"Isaiah 53:5", "Isa 53:5"},
+		{"ISA 53:5", "Isa 53:5"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			ref, err := bibleref.Parse(tc.input, tbl)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tc.input, err)
+			}
+			if ref.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, ref.String())
+			}
+		})
+	}
+}
+
+// TestParse_HyphenLookalikes tests that the minus sign and Unicode hyphen are read as range
+// separators like an ASCII hyphen.
+func TestParse_HyphenLookalikes(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		verses string
+		desc   string
+	}{
+		{"10\u221231", "minus sign U+2212"},
+		{"10\u201031", "hyphen U+2010"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := bibleref.NormalizeVerseRange(tc.verses); got != "10\u201331" {
+				t.Errorf("NormalizeVerseRange(%q): expected %q, got %q", tc.verses, "10\u201331", got)
+			}
+			input := "Prov 31:" + tc.verses
+			ref, err := bibleref.Parse(input, tbl)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", input, err)
+			}
+			if !ref.IsRange() || ref.Verse.StartVerse != 10 || *ref.Verse.EndVerse != 31 {
+				t.Errorf("expected range 10–31, got %v", ref)
+			}
+		})
+	}
+}
+
+// TestTable_MissingStandardBooks tests reporting the standard books absent from a Table.
+func TestTable_MissingStandardBooks(t *testing.T) {
+	books := []bibleref.Book{
+		{OSIS: "Jude", Name: "Jude", Testament: "NT", Order: 65, Chapters: 1},
+		{OSIS: "Wis", Name: "Wisdom of Solomon", Testament: "Apocrypha", Order: 70, Chapters: 19},
+	}
+	// every standard book except Gen, 1Sam, and Rev
+	for _, osis := range []string{
+		"Exod", "Lev", "Num", "Deut", "Josh", "Judg", "Ruth", "2Sam",
+		"1Kgs", "2Kgs", "1Chr", "2Chr", "Ezra", "Neh", "Esth", "Job", "Ps", "Prov",
+		"Eccl", "Song", "Isa", "Jer", "Lam", "Ezek", "Dan", "Hos", "Joel", "Amos",
+		"Obad", "Jonah", "Mic", "Nah", "Hab", "Zeph", "Hag", "Zech", "Mal",
+		"Matt", "Mark", "Luke", "John", "Acts", "Rom", "1Cor", "2Cor", "Gal", "Eph",
+		"Phil", "Col", "1Thess", "2Thess", "1Tim", "2Tim", "Titus", "Phlm", "Heb", "Jas",
+		"1Pet", "2Pet", "1John", "2John", "3John",
+	} {
+		books = append(books, bibleref.Book{OSIS: osis, Name: osis, Testament: "OT", Order: len(books) + 1, Chapters: 1})
+	}
+
+	tbl, err := bibleref.NewTable(books)
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	missing := tbl.MissingStandardBooks()
+	expected := []string{"Gen", "1Sam", "Rev"}
+	if len(missing) != len(expected) {
+		t.Fatalf("expected missing %v, got %v", expected, missing)
+	}
+	for i, osis := range expected {
+		if missing[i] != osis {
+			t.Errorf("missing[%d]: expected %q, got %q", i, osis, missing[i])
+		}
+	}
+
+	t.Run("OSIS codes with spaces", func(t *testing.T) {
+		spaced, err := bibleref.NewTable(append(books,
+			bibleref.Book{OSIS: "Gen", Name: "Genesis", Testament: "OT", Order: 100, Chapters: 50},
+			bibleref.Book{OSIS: "1 Sam", Name: "1 Samuel", Testament: "OT", Order: 101, Chapters: 31},
+			bibleref.Book{OSIS: "Rev", Name: "Revelation", Testament: "NT", Order: 102, Chapters: 22},
+		))
+		if err != nil {
+			t.Fatalf("NewTable failed: %v", err)
+		}
+		if missing := spaced.MissingStandardBooks(); len(missing) != 0 {
+			t.Errorf("expected no missing books, got %v", missing)
+		}
+	})
+}
+
+// TestNilReceivers tests that pointer-receiver methods return zero values for nil receivers
+// instead of panicking.
+func TestNilReceivers(t *testing.T) {
+	var ref *bibleref.BibleRef
+	if got := ref.SafeString(); got != "" {
+		t.Errorf("expected empty SafeString for nil ref, got %q", got)
+	}
+
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+	if got := bibleref.MustParse("Prov 3:5", tbl).SafeString(); got != "Prov 3:5" {
+		t.Errorf("expected %q, got %q", "Prov 3:5", got)
+	}
+
+	var info *bibleref.ParseInfo
+	if got := info.FormatPreservingInput(); got != "" {
+		t.Errorf("expected empty FormatPreservingInput for nil info, got %q", got)
+	}
+	if got := (&bibleref.ParseInfo{}).FormatPreservingInput(); got != "" {
+		t.Errorf("expected empty FormatPreservingInput without a ref, got %q", got)
+	}
+
+	var nilTbl *bibleref.Table
+	if _, ok := nilTbl.BookByOrder(1); ok {
+		t.Error("expected BookByOrder to report false for nil table")
+	}
+	if conflicts := nilTbl.AliasConflicts(); conflicts != nil {
+		t.Errorf("expected no alias conflicts for nil table, got %v", conflicts)
+	}
+	if missing := nilTbl.MissingStandardBooks(); len(missing) != 66 {
+		t.Errorf("expected all 66 standard books missing for nil table, got %d", len(missing))
+	}
+}
+
+// TestParse_VerseList tests comma-separated verse lists, their rendering, and validation of
+// each segment.
+func TestParse_VerseList(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input      string
+		expected   string
+		additional int
+	}{
+		{"Matt 5:3,5,7-9", "Matt 5:3,5,7–9", 2},
+		{"Ps 1:1,6", "Ps 1:1,6", 1},
+		{"Matt 5:3, 5, 7–9", "Matt 5:3,5,7–9", 2},
+		{"Prov 3:5-6,8", "Prov 3:5–6,8", 1},
+		{"Prov 3:5", "Prov 3:5", 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			ref, err := bibleref.Parse(tc.input, tbl)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tc.input, err)
+			}
+			if ref.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, ref.String())
+			}
+			if len(ref.Additional) != tc.additional {
+				t.Errorf("expected %d additional segments, got %d", tc.additional, len(ref.Additional))
+			}
+		})
+	}
+
+	ref := bibleref.MustParse("Matt 5:3,5,7-9", tbl)
+	if ref.IsSingleVerse() {
+		t.Error("expected a verse list not to be a single verse")
+	}
+	if count, err := ref.VerseCount(tbl); err != nil || count != 5 {
+		t.Errorf("expected 5 verses, got %d (err: %v)", count, err)
+	}
+
+	invalid := []string{
+		"Matt 5:3,0",
+		"Matt 5:3,9-7",
+		"Matt 5:3,",
+		"Matt 5:3,,5",
+	}
+	for _, input := range invalid {
+		t.Run("invalid "+input, func(t *testing.T) {
+			if ref, err := bibleref.Parse(input, tbl); err == nil {
+				t.Errorf("Parse(%q) expected error but got success: %v", input, ref)
+			}
+		})
+	}
+
+	t.Run("segment beyond verse count", func(t *testing.T) {
+		counted, err := bibleref.NewTable([]bibleref.Book{
+			{OSIS: "Ps", Name: "Psalms", Aliases: []string{"ps"}, Testament: "OT", Order: 19, Chapters: 2, VerseCounts: []int{6, 12}},
+		})
+		if err != nil {
+			t.Fatalf("NewTable failed: %v", err)
+		}
+		if _, err := bibleref.Parse("Ps 1:1,6", counted); err != nil {
+			t.Errorf("expected Ps 1:1,6 to be valid, got %v", err)
+		}
+		if _, err := bibleref.Parse("Ps 1:1,7", counted); !errors.Is(err, bibleref.ErrBibleRefParseFailed) {
+			t.Errorf("expected verse 7 of a 6-verse chapter to be rejected, got %v", err)
+		}
+	})
+}
+
+// TestParse_SteppedRanges tests the opt-in step suffix on verse ranges.
+func TestParse_SteppedRanges(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+	stepped := bibleref.WithSteppedRanges()
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"Prov 3:1-9/2", "Prov 3:1,3,5,7,9"},
+		{"Prov 3:1-10/3", "Prov 3:1,4,7,10"},
+		{"Prov 3:1-8/3", "Prov 3:1,4,7"},
+		{"Prov 3:5-7/1", "Prov 3:5,6,7"},
+		{"Prov 3:5-8", "Prov 3:5–8"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			ref, err := bibleref.Parse(tc.input, tbl, stepped)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tc.input, err)
+			}
+			if ref.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, ref.String())
+			}
+		})
+	}
+
+	invalid := []string{"Prov 3:1/2", "Prov 3:1-9/0", "Prov 3:1-9/x", "Prov 3/2", "Prov 3:5ff/2"}
+	for _, input := range invalid {
+		t.Run("invalid "+input, func(t *testing.T) {
+			if ref, err := bibleref.Parse(input, tbl, stepped); err == nil {
+				t.Errorf("Parse(%q) expected error but got success: %v", input, ref)
+			}
+		})
+	}
+
+	if ref, err := bibleref.Parse("Prov 3:1-9/2", tbl); err == nil {
+		t.Errorf("expected step suffix to be rejected without WithSteppedRanges, got %v", ref)
+	}
+}
+
+// TestParse_CompactVerseSeparator tests the opt-in 'v' separator between chapter and verse.
+func TestParse_CompactVerseSeparator(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+	compact := bibleref.WithCompactVerseSeparator()
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"Prov 3v5", "Prov 3:5"},
+		{"Prov 3v5-8", "Prov 3:5–8"},
+		{"Prov 3V5", "Prov 3:5"},
+		{"Prov3v5", "Prov 3:5"},
+		{"Prov 3 v. 5", "Prov 3:5"},
+		{"Prov 3:5", "Prov 3:5"},
+		{"Prov 3", "Prov 3"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			ref, err := bibleref.Parse(tc.input, tbl, compact)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tc.input, err)
+			}
+			if ref.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, ref.String())
+			}
+		})
+	}
+
+	invalid := []string{"Prov 3vv5", "Prov 3v", "Prov v5"}
+	for _, input := range invalid {
+		t.Run("invalid "+input, func(t *testing.T) {
+			if ref, err := bibleref.Parse(input, tbl, compact); err == nil {
+				t.Errorf("Parse(%q) expected error but got success: %v", input, ref)
+			}
+		})
+	}
+
+	if ref, err := bibleref.Parse("Prov 3v5", tbl); err == nil {
+		t.Errorf("expected 'v' separator to be rejected without WithCompactVerseSeparator, got %v", ref)
+	}
+}
+
+// TestParse_SpacedSeparators tests spaces around the chapter-verse colon and the range dash.
+func TestParse_SpacedSeparators(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"Prov 31 : 10 - 31", "Prov 31:10–31"},
+		{"Prov 31: 10-31", "Prov 31:10–31"},
+		{"Prov 31 :10-31", "Prov 31:10–31"},
+		{"Prov 31:10 - 31", "Prov 31:10–31"},
+		{"Prov 31:10 -31", "Prov 31:10–31"},
+		{"Prov 31:10- 31", "Prov 31:10–31"},
+		{"Prov 31:10 \u2013 31", "Prov 31:10–31"},
+		{"Gen 1:31 - 2:3", "Gen 1:31–2:3"},
+		{"1 Sam 3 : 4", "1Sam 3:4"},
+		{"Prov 3 : 5, 7", "Prov 3:5,7"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			ref, err := bibleref.Parse(tc.input, tbl)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tc.input, err)
+			}
+			if ref.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, ref.String())
+			}
+		})
+	}
+
+	invalid := []string{"Prov 31:10 - Matt 1", "Prov 31 : ", "Prov : 10"}
+	for _, input := range invalid {
+		t.Run("invalid "+input, func(t *testing.T) {
+			if ref, err := bibleref.Parse(input, tbl); err == nil {
+				t.Errorf("Parse(%q) expected error but got success: %v", input, ref)
+			}
+		})
+	}
+}
+
+// TestParse_IntervalNotation tests the opt-in interval notation with inclusive and exclusive
+// endpoints.
+func TestParse_IntervalNotation(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+	intervals := bibleref.WithIntervalNotation()
+
+	testCases := []struct {
+		input    string
+		expected string
+		desc     string
+	}{
+		{"Prov [3:5,3:8]", "Prov 3:5–8", "closed"},
+		{"Prov [3:5,3:8)", "Prov 3:5–7", "half-open"},
+		{"Prov (3:5,3:8]", "Prov 3:6–8", "open start"},
+		{"Prov (3:5,3:8)", "Prov 3:6–7", "open"},
+		{"Prov [3:5,3:6)", "Prov 3:5", "single verse"},
+		{"Prov [3:5, 8)", "Prov 3:5–7", "end verse in start chapter"},
+		{"Gen [1:30,2:4)", "Gen 1:30–2:3", "cross-chapter"},
+		{"Prov 3:5-8", "Prov 3:5–8", "ordinary range"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ref, err := bibleref.Parse(tc.input, tbl, intervals)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tc.input, err)
+			}
+			if ref.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, ref.String())
+			}
+		})
+	}
+
+	invalid := []string{"Prov [3:5,3:5)", "Prov [3:8,3:5]", "Prov [3:5,4:1)", "Prov [3:5]", "Prov [3:5,3:8", "Prov [x,3:8]"}
+	for _, input := range invalid {
+		t.Run("invalid "+input, func(t *testing.T) {
+			if ref, err := bibleref.Parse(input, tbl, intervals); err == nil {
+				t.Errorf("Parse(%q) expected error but got success: %v", input, ref)
+			}
+		})
+	}
+
+	if ref, err := bibleref.Parse("Prov [3:5,3:8)", tbl); err == nil {
+		t.Errorf("expected interval to be rejected without WithIntervalNotation, got %v", ref)
+	}
+}
+
+// TestParse_ChapterRange tests ranges of whole chapters without verses.
+func TestParse_ChapterRange(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input      string
+		expected   string
+		endChapter int
+		desc       string
+	}{
+		{"Gen 1-3", "Gen 1–3", 3, "hyphen"},
+		{"Genesis 1–3", "Gen 1–3", 3, "en dash"},
+		{"Gen 1 - 3", "Gen 1–3", 3, "spaced dash"},
+		{"Gen 49-50", "Gen 49–50", 50, "ends at last chapter"},
+		{"Gen 1–1", "Gen 1", 0, "degenerate range"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ref, err := bibleref.Parse(tc.input, tbl)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tc.input, err)
+			}
+			if ref.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, ref.String())
+			}
+			if ref.Verse != nil || !ref.IsChapterOnly() {
+				t.Errorf("expected a chapter-only reference, got verse %v", ref.Verse)
+			}
+			if tc.endChapter == 0 {
+				if ref.EndChapter != nil {
+					t.Errorf("expected no end chapter, got %d", *ref.EndChapter)
+				}
+			} else if ref.EndChapter == nil || *ref.EndChapter != tc.endChapter {
+				t.Errorf("expected end chapter %d, got %v", tc.endChapter, ref.EndChapter)
+			}
+		})
+	}
+
+	invalid := []string{"Gen 3-1", "Gen 1-51", "Gen 1-3x", "Gen 1-"}
+	for _, input := range invalid {
+		t.Run("invalid "+input, func(t *testing.T) {
+			if ref, err := bibleref.Parse(input, tbl); err == nil {
+				t.Errorf("Parse(%q) expected error but got success: %v", input, ref)
+			}
+		})
+	}
+
+	counted, err := bibleref.NewTable([]bibleref.Book{
+		{OSIS: "Ruth", Name: "Ruth", Testament: "OT", Order: 8, Chapters: 4, VerseCounts: []int{22, 23, 18, 22}},
+	})
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+	if count, err := bibleref.MustParse("Ruth 2-3", counted).VerseCount(counted); err != nil || count != 41 {
+		t.Errorf("expected 41 verses in Ruth 2–3, got %d (err: %v)", count, err)
+	}
+}
+
+// TestParse_OSISForm tests reading the period-delimited OSIS form and round-tripping
+// FormatOSIS output.
+func TestParse_OSISForm(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected string
+		osis     string
+	}{
+		{"Prov 31:10-31", "Prov 31:10–31", "Prov.31.10–31"},
+		{"Prov 3:5", "Prov 3:5", "Prov.3.5"},
+		{"Prov 3", "Prov 3", "Prov.3"},
+		{"1 Sam 3:4", "1Sam 3:4", "1Sam.3.4"},
+		{"Gen 1:31-2:3", "Gen 1:31–2:3", "Gen.1.31–2.3"},
+		{"Prov 3:5,7-9", "Prov 3:5,7–9", "Prov.3.5,7–9"},
+		{"Gen 1-3", "Gen 1–3", "Gen.1–3"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			ref := bibleref.MustParse(tc.input, tbl)
+			osis := ref.Format(bibleref.FormatOSIS, tbl)
+			if osis != tc.osis {
+				t.Fatalf("expected OSIS form %q, got %q", tc.osis, osis)
+			}
+			reparsed, err := bibleref.Parse(osis, tbl)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", osis, err)
+			}
+			if !reparsed.Equal(*ref) || reparsed.String() != tc.expected {
+				t.Errorf("expected %q to parse back to %q, got %q", osis, tc.expected, reparsed)
+			}
+		})
+	}
+
+	variants := []struct {
+		input    string
+		expected string
+	}{
+		{"Prov.31.10-31", "Prov 31:10–31"},
+		{"prov.3.5", "Prov 3:5"},
+		{"Prov. 3:5", "Prov 3:5"},
+	}
+	for _, v := range variants {
+		if ref, err := bibleref.Parse(v.input, tbl); err != nil || ref.String() != v.expected {
+			t.Errorf("Parse(%q) = %v, %v; expected %q", v.input, ref, err, v.expected)
+		}
+	}
+}
+
+// TestBibleRef_Parent tests stepping from a verse to its chapter and then to its book.
+func TestBibleRef_Parent(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"Prov 3:5", "Prov 3"},
+		{"Prov 3:5-8", "Prov 3"},
+		{"Matt 5:3,5,7-9", "Matt 5"},
+		{"Gen 1:30-2:3", "Gen 1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			ref := bibleref.MustParse(tc.input, tbl)
+			if got := ref.ChapterRef(); got.String() != tc.expected {
+				t.Errorf("ChapterRef: expected %q, got %q", tc.expected, got.String())
+			}
+
+			chapter := ref.Parent()
+			if chapter == nil || chapter.String() != tc.expected || !chapter.IsChapterOnly() {
+				t.Fatalf("expected parent %q, got %v", tc.expected, chapter)
+			}
+			if book := chapter.Parent(); book != nil {
+				t.Errorf("expected nil parent for chapter %q, got %v", chapter, book)
+			}
+			if _, ok := tbl.ByOsis[chapter.OSIS]; !ok {
+				t.Errorf("expected chapter OSIS %q to identify a book", chapter.OSIS)
+			}
+		})
+	}
+}
+
+// TestParseDetailed_NoteMarker tests that trailing note markers are stripped and reported.
+func TestParseDetailed_NoteMarker(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected string
+		marker   string
+		desc     string
+	}{
+		{"Prov 3:5†", "Prov 3:5", "†", "dagger"},
+		{"Prov 3:5*", "Prov 3:5", "*", "asterisk"},
+		{"Prov 3:5–6‡", "Prov 3:5–6", "‡", "double dagger after range"},
+		{"Prov 3:5 §", "Prov 3:5", "§", "spaced section sign"},
+		{"Prov 3:5**", "Prov 3:5", "**", "repeated marker"},
+		{"Prov 3:5", "Prov 3:5", "", "no marker"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			info, err := bibleref.ParseDetailed(tc.input, tbl)
+			if err != nil {
+				t.Fatalf("ParseDetailed(%q) failed: %v", tc.input, err)
+			}
+			if info.Ref.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, info.Ref.String())
+			}
+			if info.Marker != tc.marker {
+				t.Errorf("expected Marker %q, got %q", tc.marker, info.Marker)
+			}
+		})
+	}
+}
+
+// TestParse_MaxInputLength tests that input over the length limit is rejected before parsing.
+func TestParse_MaxInputLength(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	padded := func(n int) string {
+		return strings.Repeat(" ", n-len("Prov 3:5")) + "Prov 3:5"
+	}
+	testCases := []struct {
+		input   string
+		opts    []bibleref.ParseOption
+		tooLong bool
+		desc    string
+	}{
+		{padded(bibleref.DefaultMaxInputLength), nil, false, "at the default limit"},
+		{padded(bibleref.DefaultMaxInputLength + 1), nil, true, "just over the default limit"},
+		{padded(16), []bibleref.ParseOption{bibleref.WithMaxInputLength(16)}, false, "at a custom limit"},
+		{padded(17), []bibleref.ParseOption{bibleref.WithMaxInputLength(16)}, true, "just over a custom limit"},
+		{padded(1 << 20), []bibleref.ParseOption{bibleref.WithMaxInputLength(0)}, false, "limit disabled"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ref, err := bibleref.Parse(tc.input, tbl, tc.opts...)
+			if tc.tooLong {
+				if !errors.Is(err, bibleref.ErrInputTooLong) {
+					t.Fatalf("expected ErrInputTooLong, got %v", err)
+				}
+				if strings.Contains(err.Error(), "Prov") {
+					t.Errorf("expected the error not to echo the input, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			if ref.String() != "Prov 3:5" {
+				t.Errorf("expected %q, got %q", "Prov 3:5", ref.String())
+			}
+		})
+	}
+}
+
+// TestNormalizeAlias_RomanPrefix tests that roman numerals are converted only at the start of
+// a numbered book name.
+func TestNormalizeAlias_RomanPrefix(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"i john", "1 john"},
+		{"II Samuel", "2 samuel"},
+		{"iii john", "3 john"},
+		{"isam", "1sam"},
+		{"iijohn", "2john"},
+		{"iiijohn", "3john"},
+		{"isaiah", "isaiah"},
+		{"isa", "isa"},
+		{"job", "job"},
+		{"levi i", "levi i"},
+		{"song of songs ii", "song of songs ii"},
+		{"i", "i"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			if got := bibleref.NormalizeAlias(tc.input); got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+// TestParseDetailed_MatchedAlias tests that the normalized alias that resolved the book is reported.
+func TestParseDetailed_MatchedAlias(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected string
+		desc     string
+	}{
+		{"Prov 3:5", "prov", "OSIS spelling"},
+		{"Proverbs 3:5", "proverbs", "full name"},
+		{"Pro. 3:5", "pro", "abbreviation with period"},
+		{"PROVERBS 3:5", "proverbs", "upper case"},
+		{"I Sam 3:1", "1 sam", "roman numeral prefix"},
+		{"the proverbs 3:5", "proverbs", "leading article"},
+		{"Gn 1:1", "gn", "short alias"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			info, err := bibleref.ParseDetailed(tc.input, tbl)
+			if err != nil {
+				t.Fatalf("ParseDetailed(%q) failed: %v", tc.input, err)
+			}
+			if info.MatchedAlias != tc.expected {
+				t.Errorf("expected MatchedAlias %q, got %q", tc.expected, info.MatchedAlias)
+			}
+		})
+	}
+}
+
+// TestNewTable_NonInitialRomanNumerals tests that aliases with "i" or "ii" as a later word are
+// stored unchanged, while a leading numeral is still converted.
+func TestNewTable_NonInitialRomanNumerals(t *testing.T) {
+	tbl, err := bibleref.NewTable([]bibleref.Book{
+		{OSIS: "Song", Name: "Song of Solomon", Aliases: []string{"song of solomon i", "canticle i of ii"}, Testament: bibleref.TestamentOld, Order: 22, Chapters: 8},
+		{OSIS: "Acts", Name: "Acts", Aliases: []string{"acts ii", "luke ii"}, Testament: bibleref.TestamentNew, Order: 44, Chapters: 28},
+		{OSIS: "1John", Name: "1 John", Aliases: []string{"i john"}, Testament: bibleref.TestamentNew, Order: 62, Chapters: 5},
+	})
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		alias string
+		osis  string
+	}{
+		{"song of solomon i", "Song"},
+		{"canticle i of ii", "Song"},
+		{"acts ii", "Acts"},
+		{"luke ii", "Acts"},
+		{"1 john", "1John"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.alias, func(t *testing.T) {
+			if got := tbl.ByAlias[tc.alias]; got != tc.osis {
+				t.Errorf("expected alias %q to map to %q, got %q", tc.alias, tc.osis, got)
+			}
+		})
+	}
+	for _, corrupted := range []string{"song of solomon 1", "canticle 1 of ii", "acts 2"} {
+		if _, ok := tbl.ByAlias[corrupted]; ok {
+			t.Errorf("unexpected rewritten alias %q in table", corrupted)
+		}
+	}
+}
+
+// TestParse_VerseParts tests parsing and rendering of verse part letters.
+func TestParse_VerseParts(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input       string
+		expected    string
+		expectError bool
+		desc        string
+	}{
+		{"Ps 23:1b", "Ps 23:1b", false, "single verse with part"},
+		{"Matt 5:3a-5b", "Matt 5:3a–5b", false, "range with parts"},
+		{"Matt 5:3-5b", "Matt 5:3–5b", false, "part on end verse only"},
+		{"Matt 5:3a,7c", "Matt 5:3a,7c", false, "verse list with parts"},
+		{"Gen 1:30b-2:3a", "Gen 1:30b–2:3a", false, "cross-chapter range with parts"},
+		{"Matt 5:3a-3b", "Matt 5:3a–3b", false, "parts of one verse"},
+		{"Ps 23:1e", "", true, "part beyond d"},
+		{"Ps 23:1B", "", true, "uppercase part"},
+		{"Ps 23:1ab", "", true, "two letters"},
+		{"Matt 5:3b-3a", "", true, "parts out of order"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ref, err := bibleref.Parse(tc.input, tbl)
+			if tc.expectError {
+				if !errors.Is(err, bibleref.ErrInvalidVerse) {
+					t.Errorf("expected ErrInvalidVerse for %q, got %v, %v", tc.input, ref.SafeString(), err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tc.input, err)
+			}
+			if ref.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, ref.String())
+			}
+		})
+	}
+
+	ref := bibleref.MustParse("Matt 5:3a-5b", tbl)
+	if ref.Verse.StartVerse != 3 || ref.Verse.StartPart != "a" || *ref.Verse.EndVerse != 5 || ref.Verse.EndPart != "b" {
+		t.Errorf("unexpected verse range %+v", *ref.Verse)
+	}
+}
+
+// TestNewRefConstructors tests building references without parsing, and that the constructors
+// validate against the Table like Parse.
+func TestNewRefConstructors(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		build    func() (*bibleref.BibleRef, error)
+		expected string
+		kind     bibleref.ErrKind
+		desc     string
+	}{
+		{func() (*bibleref.BibleRef, error) { return bibleref.NewChapterRef("Prov", 3, tbl) }, "Prov 3", 0, "chapter"},
+		{func() (*bibleref.BibleRef, error) { return bibleref.NewVerseRef("Prov", 3, 5, tbl) }, "Prov 3:5", 0, "verse"},
+		{func() (*bibleref.BibleRef, error) { return bibleref.NewRangeRef("Prov", 3, 5, 8, tbl) }, "Prov 3:5–8", 0, "range"},
+		{func() (*bibleref.BibleRef, error) { return bibleref.NewChapterRef("Xyz", 1, tbl) }, "", bibleref.KindUnknownBook, "unknown book"},
+		{func() (*bibleref.BibleRef, error) { return bibleref.NewChapterRef("Prov", 32, tbl) }, "", bibleref.KindInvalidChapter, "chapter out of range"},
+		{func() (*bibleref.BibleRef, error) { return bibleref.NewVerseRef("Prov", 3, 0, tbl) }, "", bibleref.KindInvalidVerse, "zero verse"},
+		{func() (*bibleref.BibleRef, error) { return bibleref.NewRangeRef("Prov", 3, 8, 5, tbl) }, "", bibleref.KindInvalidVerse, "reversed range"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ref, err := tc.build()
+			if tc.expected == "" {
+				var refErr *bibleref.BibleRefError
+				if !errors.As(err, &refErr) || refErr.Kind != tc.kind {
+					t.Fatalf("expected BibleRefError of kind %v, got %v", tc.kind, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ref.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, ref.String())
+			}
+			if parsed := bibleref.MustParse(tc.expected, tbl); !parsed.Equal(*ref) {
+				t.Errorf("expected %v to equal the parsed %v", ref, parsed)
+			}
+		})
+	}
+}
+
+// TestRegisterOrdinalPrefix tests extending the roman numeral prefixes beyond the defaults.
+func TestRegisterOrdinalPrefix(t *testing.T) {
+	t.Cleanup(bibleref.ResetOrdinalPrefixes)
+	if got := bibleref.NormalizeAlias("IV Maccabees"); got != "iv maccabees" {
+		t.Fatalf("expected %q before registering, got %q", "iv maccabees", got)
+	}
+
+	if err := bibleref.RegisterOrdinalPrefix("IV", "4"); err != nil {
+		t.Fatalf("RegisterOrdinalPrefix failed: %v", err)
+	}
+	books := append(testBooks(), bibleref.Book{
+		OSIS: "4Macc", Name: "4 Maccabees", Aliases: []string{"4 maccabees", "4 macc"}, Testament: "Apocrypha", Order: 80, Chapters: 18,
+	})
+	tbl, err := bibleref.NewTable(books)
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"IV Maccabees 1:1", "4Macc 1:1"},
+		{"iv macc 18", "4Macc 18"},
+		{"I Sam 3:1", "1Sam 3:1"},
+		{"IISam 1", "2Sam 1"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			ref, err := bibleref.Parse(tc.input, tbl)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tc.input, err)
+			}
+			if ref.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, ref.String())
+			}
+		})
+	}
+
+	if got := bibleref.NormalizeAlias("ivan"); got != "ivan" {
+		t.Errorf("expected a registered prefix joined to a word to be left alone, got %q", got)
+	}
+	invalid := []struct {
+		prefix, digit string
+	}{
+		{"", "4"},
+		{"iv", "four"},
+		{"i-v", "4"},
+	}
+	for _, tc := range invalid {
+		if err := bibleref.RegisterOrdinalPrefix(tc.prefix, tc.digit); !errors.Is(err, bibleref.ErrInvalidBook) {
+			t.Errorf("expected ErrInvalidBook for %q → %q, got %v", tc.prefix, tc.digit, err)
+		}
+	}
+
+	bibleref.ResetOrdinalPrefixes()
+	if got := bibleref.NormalizeAlias("iv maccabees"); got != "iv maccabees" {
+		t.Errorf("expected %q after reset, got %q", "iv maccabees", got)
+	}
+}
+
+// TestNewTable_ReportsAllInvalidBooks tests that every invalid book is reported with its index
+// and OSIS code in a single joined error.
+func TestNewTable_ReportsAllInvalidBooks(t *testing.T) {
+	books := append(testBooks(),
+		bibleref.Book{OSIS: "Ruth", Testament: bibleref.TestamentOld, Order: 8, Chapters: 4},
+		bibleref.Book{OSIS: "Esth", Name: "Esther", Testament: bibleref.TestamentOld, Order: 17},
+		bibleref.Book{OSIS: "Jas", Name: "James", Testament: "Epistles", Order: 59, Chapters: 5},
+	)
+	_, err := bibleref.NewTable(books)
+	if !errors.Is(err, bibleref.ErrInvalidBook) {
+		t.Fatalf("expected ErrInvalidBook, got %v", err)
+	}
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("expected a joined error, got %T", err)
+	}
+	if got := len(joined.Unwrap()); got != 3 {
+		t.Errorf("expected 3 errors, got %d: %v", got, err)
+	}
+	for _, want := range []string{`book 8 ("Ruth")`, `book 9 ("Esth")`, `book 10 ("Jas")`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to mention %s, got %v", want, err)
+		}
+	}
+}
+
+// TestIsCanonical tests that only input equal to its canonical rendering is reported canonical.
+func TestIsCanonical(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input       string
+		expected    bool
+		expectError bool
+	}{
+		{"Prov 31:10–31", true, false},
+		{"Matt 5", true, false},
+		{"Jude 1:4", true, false},
+		{"proverbs 31:10-31", false, false},
+		{"Prov 31:10-31", false, false},
+		{" Prov 31:10–31", false, false},
+		{"Jude 4", false, false},
+		{"Prov 32:1", false, true},
+		{"not a reference", false, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := bibleref.IsCanonical(tc.input, tbl)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected an error for %q", tc.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("IsCanonical(%q) failed: %v", tc.input, err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+// TestParse_FollowingVerses tests the "f" and "ff" notation for a verse and those after it.
+func TestParse_FollowingVerses(t *testing.T) {
+	books := append(testBooks(), bibleref.Book{
+		OSIS: "Ruth", Name: "Ruth", Aliases: []string{"ruth"}, Testament: bibleref.TestamentOld, Order: 8, Chapters: 4,
+		VerseCounts: []int{22, 23, 18, 22},
+	})
+	tbl, err := bibleref.NewTable(books)
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input       string
+		expected    string
+		endVerse    int
+		expectError bool
+		desc        string
+	}{
+		{"Prov 3:5f", "Prov 3:5f", 6, false, "following verse"},
+		{"Prov 3:5ff", "Prov 3:5ff", 0, false, "following verses without verse counts"},
+		{"Prov 3:5 ff", "Prov 3:5ff", 0, false, "spaced notation"},
+		{"Prov 3:5 ff.", "Prov 3:5ff", 0, false, "spaced notation with period"},
+		{"Prov 3: 5f.", "Prov 3:5f", 6, false, "spaced separator with period"},
+		{"Ruth 1:20ff", "Ruth 1:20ff", 22, false, "following verses to the end of the chapter"},
+		{"Ruth 1:22ff", "Ruth 1:22ff", 0, false, "last verse of the chapter"},
+		{"Ruth 1:22f", "", 0, true, "no following verse"},
+		{"Prov 3:5-6ff", "", 0, true, "notation after a range"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ref, err := bibleref.Parse(tc.input, tbl)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected an error for %q, got %v", tc.input, ref)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tc.input, err)
+			}
+			if ref.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, ref.String())
+			}
+			end := 0
+			if ref.Verse.EndVerse != nil {
+				end = *ref.Verse.EndVerse
+			}
+			if end != tc.endVerse {
+				t.Errorf("expected end verse %d, got %d", tc.endVerse, end)
+			}
+			if !ref.IsRange() || ref.IsSingleVerse() {
+				t.Errorf("expected %q to be a range and not a single verse", tc.input)
+			}
+		})
+	}
+
+	if count, err := bibleref.MustParse("Ruth 1:20ff", tbl).VerseCount(tbl); err != nil || count != 3 {
+		t.Errorf("expected 3 verses for Ruth 1:20ff, got %d, %v", count, err)
+	}
+}
+
+// TestParse_DanglingColon tests that a chapter with a trailing colon is rejected by default and
+// read as chapter-only with WithDanglingColon.
+func TestParse_DanglingColon(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"Prov 3:", "Prov 3"},
+		{"Prov 3 :", "Prov 3"},
+		{"Ps 119:", "Ps 119"},
+		{"Prov 3::", "Prov 3"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			if _, err := bibleref.Parse(tc.input, tbl); !errors.Is(err, bibleref.ErrInvalidVerse) {
+				t.Errorf("expected ErrInvalidVerse by default, got %v", err)
+			}
+			ref, err := bibleref.Parse(tc.input, tbl, bibleref.WithDanglingColon())
+			if err != nil {
+				t.Fatalf("Parse(%q) with WithDanglingColon failed: %v", tc.input, err)
+			}
+			if ref.String() != tc.expected || !ref.IsChapterOnly() {
+				t.Errorf("expected chapter-only %q, got %q", tc.expected, ref.String())
+			}
+		})
+	}
+}
+
+// TestParse_WithoutValidation tests that WithoutValidation parses references structurally,
+// leaving unknown books and out-of-range numbers for Validate to reject.
+func TestParse_WithoutValidation(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected string
+		validErr error
+	}{
+		{"Foo 3:5", "foo 3:5", bibleref.ErrInvalidOSISCode},
+		{"Prov 32", "Prov 32", bibleref.ErrInvalidChapter},
+		{"proverbs 3:5-8", "Prov 3:5–8", nil},
+		{"Jude 4", "Jude 1:4", nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			if _, err := bibleref.Parse(tc.input, tbl); (err == nil) != (tc.validErr == nil) {
+				t.Errorf("Parse(%q) without the option returned %v", tc.input, err)
+			}
+			ref, err := bibleref.Parse(tc.input, tbl, bibleref.WithoutValidation())
+			if err != nil {
+				t.Fatalf("Parse(%q) with WithoutValidation failed: %v", tc.input, err)
+			}
+			if ref.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, ref.String())
+			}
+			if err := ref.Validate(tbl); !errors.Is(err, tc.validErr) {
+				t.Errorf("Validate() = %v, expected %v", err, tc.validErr)
+			}
+		})
+	}
+
+	for _, input := range []string{"Foo", "Prov 3:x", "Prov 3:0"} {
+		if _, err := bibleref.Parse(input, tbl, bibleref.WithoutValidation()); err == nil {
+			t.Errorf("expected structural error for %q", input)
+		}
+	}
+	if _, err := bibleref.Parse("Foo -1", tbl, bibleref.WithoutValidation(), bibleref.WithRelativeChapters()); err == nil {
+		t.Error("expected error for relative chapter of an unknown book")
+	}
+}
+
+// TestTable_AliasToOSIS tests resolving aliases to OSIS codes.
+func TestTable_AliasToOSIS(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		alias    string
+		expected string
+		ok       bool
+	}{
+		{"proverbs", "Prov", true},
+		{"Prov.", "Prov", true},
+		{"MT", "Matt", true},
+		{"Gen", "Gen", true},
+		{"nope", "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.alias, func(t *testing.T) {
+			osis, ok := tbl.AliasToOSIS(tc.alias)
+			if osis != tc.expected || ok != tc.ok {
+				t.Errorf("AliasToOSIS(%q) = %q, %v; expected %q, %v", tc.alias, osis, ok, tc.expected, tc.ok)
+			}
+		})
+	}
+
+	var nilTable *bibleref.Table
+	if _, ok := nilTable.AliasToOSIS("prov"); ok {
+		t.Error("expected nil Table to resolve no alias")
+	}
+}
+
+// TestTable_Freeze tests that a frozen Table is independent of the original and safe for
+// concurrent lookups. Run with -race to check the latter.
+func TestTable_Freeze(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+	frozen := tbl.Freeze()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if ref, err := bibleref.Parse("proverbs 31:10-31", frozen); err != nil || ref.OSIS != "Prov" {
+					t.Errorf("Parse on frozen table = %v, %v", ref, err)
+					return
+				}
+				if _, ok := frozen.Lookup("II Sam"); !ok {
+					t.Error("expected frozen table to resolve II Sam")
+					return
+				}
+				if osis, _ := frozen.AliasToOSIS("mt"); osis != "Matt" {
+					t.Errorf("expected mt to resolve to Matt, got %q", osis)
+					return
+				}
+				frozen.BooksInOrder()
+			}
+		}()
+	}
+	for i := 0; i < 100; i++ {
+		tbl.ByAlias["alias"+strings.Repeat("x", i)] = "Gen"
+	}
+	wg.Wait()
+
+	if _, ok := frozen.AliasToOSIS("alias"); ok {
+		t.Error("expected frozen table to be unaffected by writes to the original")
+	}
+	if book, ok := frozen.BookByOrder(20); !ok || book.OSIS != "Prov" {
+		t.Errorf("expected BookByOrder(20) to be Prov, got %v, %v", book.OSIS, ok)
+	}
+	if book, ok := frozen.Get("Prov"); !ok || book.Name != "Proverbs" {
+		t.Errorf("expected Get to return Proverbs, got %v, %v", book.Name, ok)
+	}
+
+	ruth, err := bibleref.NewTable([]bibleref.Book{{OSIS: "Ruth", Name: "Ruth", Aliases: []string{"ruth"}, Testament: "OT", Order: 8, Chapters: 4, VerseCounts: []int{22, 23, 18, 22}}})
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+	frozenRuth := ruth.Freeze()
+	original, _ := ruth.Get("Ruth")
+	original.Aliases[0] = "changed"
+	original.VerseCounts[0] = 99
+	if book, _ := frozenRuth.Get("Ruth"); book.Aliases[0] != "ruth" || book.VerseCounts[0] != 22 {
+		t.Errorf("expected frozen book slices to be copies, got %v and %v", book.Aliases, book.VerseCounts)
+	}
+
+	var nilTable *bibleref.Table
+	if nilTable.Freeze() != nil {
+		t.Error("expected Freeze of nil Table to be nil")
+	}
+}
+
+// TestBibleRef_Meta tests that Meta does not affect equality, ordering, or formatting.
+func TestBibleRef_Meta(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	plain := bibleref.MustParse("Prov 31:10-31", tbl)
+	annotated := *plain
+	annotated.Meta = map[string]string{"doc": "sermon-12", "offset": "418"}
+
+	if !annotated.Equal(*plain) || !plain.Equal(annotated) {
+		t.Error("expected Meta to be ignored by Equal")
+	}
+	if c := annotated.Compare(*plain, tbl); c != 0 {
+		t.Errorf("expected Compare to ignore Meta, got %d", c)
+	}
+	if annotated.String() != plain.String() || annotated.Format(bibleref.FormatHuman, tbl) != plain.Format(bibleref.FormatHuman, tbl) {
+		t.Errorf("expected Meta to be ignored by formatting, got %q", annotated.String())
+	}
+
+	data, err := json.Marshal(annotated)
+	if err != nil {
+		t.Fatalf("MarshalJSON failed: %v", err)
+	}
+	want, _ := json.Marshal(plain)
+	if string(data) != string(want) {
+		t.Errorf("expected JSON %s without Meta, got %s", want, data)
+	}
+}
+
+// TestParse_VerseZero tests that verse 0 is accepted only for books with AllowVerseZero.
+func TestParse_VerseZero(t *testing.T) {
+	strict, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+	books := testBooks()
+	for i := range books {
+		books[i].AllowVerseZero = books[i].OSIS == "Ps"
+	}
+	allowed, err := bibleref.NewTable(books)
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected string
+		allowed  bool
+	}{
+		{"Ps 51:0", "Ps 51:0", true},
+		{"Ps 51:0-2", "Ps 51:0–2", true},
+		{"Ps 51:0,3", "Ps 51:0,3", true},
+		{"Ps 50:3-51:0", "Ps 50:3–51:0", true},
+		{"Prov 3:0", "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			if _, err := bibleref.Parse(tc.input, strict); !errors.Is(err, bibleref.ErrInvalidVerse) {
+				t.Errorf("expected ErrInvalidVerse by default, got %v", err)
+			}
+			ref, err := bibleref.Parse(tc.input, allowed)
+			if !tc.allowed {
+				if !errors.Is(err, bibleref.ErrInvalidVerse) {
+					t.Errorf("expected ErrInvalidVerse for a book without AllowVerseZero, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tc.input, err)
+			}
+			if ref.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, ref.String())
+			}
+			if err := ref.Validate(strict); !errors.Is(err, bibleref.ErrInvalidVerse) {
+				t.Errorf("expected strict Validate to reject %q, got %v", tc.input, err)
+			}
+		})
+	}
+}
+
+// TestParse_OverlappingVerseList tests that overlapping segments of one verse list are merged
+// by default and rejected with WithStrictVerseLists.
+func TestParse_OverlappingVerseList(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected string
+		strictOk bool
+	}{
+		{"Prov 3:5,5-8", "Prov 3:5–8", false},
+		{"Prov 3:5,5", "Prov 3:5", false},
+		{"Prov 3:5-8,10,6-7", "Prov 3:5–8,10", false},
+		{"Prov 3:10,1-3,2-12", "Prov 3:1–12", false},
+		{"Prov 3:5,7,2-9", "Prov 3:2–9", false},
+		{"Prov 3:5,6", "Prov 3:5,6", true},
+		{"Prov 3:5a,5b", "Prov 3:5a,5b", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			ref, err := bibleref.Parse(tc.input, tbl)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tc.input, err)
+			}
+			if ref.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, ref.String())
+			}
+			_, err = bibleref.Parse(tc.input, tbl, bibleref.WithStrictVerseLists())
+			if tc.strictOk && err != nil {
+				t.Errorf("strict Parse(%q) failed: %v", tc.input, err)
+			}
+			if !tc.strictOk && !errors.Is(err, bibleref.ErrInvalidVerse) {
+				t.Errorf("strict Parse(%q): expected ErrInvalidVerse, got %v", tc.input, err)
+			}
+		})
+	}
+
+	ruth, err := bibleref.NewTable([]bibleref.Book{{OSIS: "Ruth", Name: "Ruth", Testament: "OT", Order: 8, Chapters: 4, VerseCounts: []int{22, 23, 18, 22}}})
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+	if n, err := bibleref.MustParse("Ruth 1:5,5-8", ruth).VerseCount(ruth); err != nil || n != 4 {
+		t.Errorf("expected VerseCount 4 for Ruth 1:5,5-8, got %d, %v", n, err)
+	}
+}
@@ -0,0 +1,74 @@
+package bibleref
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/julianstephens/canonref/util"
+)
+
+// TableBuilder assembles a Table one book at a time through a fluent API, e.g.
+//
+//	tbl, err := NewTableBuilder().
+//		AddBook(Book{OSIS: "Prov", Name: "Proverbs", Testament: "OT", Order: 20, Chapters: 31}).
+//		AddAlias("Prov", "pr").
+//		Build()
+//
+// Mistakes made while building, such as adding a book twice or an alias for a book that was
+// never added, are reported by Build rather than by the individual methods.
+type TableBuilder struct {
+	books []Book
+	index map[string]int
+	err   error
+}
+
+// NewTableBuilder returns an empty TableBuilder.
+func NewTableBuilder() *TableBuilder {
+	return &TableBuilder{index: make(map[string]int)}
+}
+
+// AddBook adds book to the Table being built. Its aliases are copied, so later calls to
+// AddAlias do not modify the caller's slice.
+func (b *TableBuilder) AddBook(book Book) *TableBuilder {
+	if _, exists := b.index[book.OSIS]; exists {
+		b.fail(fmt.Sprintf("book %q added more than once", book.OSIS))
+		return b
+	}
+	book.Aliases = slices.Clone(book.Aliases)
+	b.index[book.OSIS] = len(b.books)
+	b.books = append(b.books, book)
+	return b
+}
+
+// AddAlias adds alias to the book with the given OSIS code, which must already have been
+// added with AddBook.
+func (b *TableBuilder) AddAlias(osis, alias string) *TableBuilder {
+	i, exists := b.index[osis]
+	if !exists {
+		b.fail(fmt.Sprintf("alias %q added for unknown book %q", alias, osis))
+		return b
+	}
+	b.books[i].Aliases = append(b.books[i].Aliases, alias)
+	return b
+}
+
+// Build returns the Table built from the added books, validated as by NewTable. It returns
+// the first error recorded while building, if any.
+func (b *TableBuilder) Build() (*Table, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	return NewTable(b.books)
+}
+
+// fail records the first error made while building.
+func (b *TableBuilder) fail(msg string) {
+	if b.err != nil {
+		return
+	}
+	b.err = &BibleRefError{
+		Kind:    KindInvalidBook,
+		Err:     ErrInvalidBook,
+		Message: util.Ptr(msg),
+	}
+}
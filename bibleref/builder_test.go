@@ -0,0 +1,66 @@
+package bibleref_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/julianstephens/canonref/bibleref"
+)
+
+// TestTableBuilder tests building a Table fluently and parsing against it.
+func TestTableBuilder(t *testing.T) {
+	tbl, err := bibleref.NewTableBuilder().
+		AddBook(bibleref.Book{OSIS: "Gen", Name: "Genesis", Testament: "OT", Order: 1, Chapters: 50}).
+		AddBook(bibleref.Book{OSIS: "Prov", Name: "Proverbs", Aliases: []string{"proverbs"}, Testament: "OT", Order: 20, Chapters: 31}).
+		AddAlias("Gen", "gn").
+		AddAlias("Prov", "pr").
+		Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected string
+		desc     string
+	}{
+		{"Gen 1:1", "Gen 1:1", "OSIS code"},
+		{"gn 1:1", "Gen 1:1", "added alias"},
+		{"Proverbs 3:5", "Prov 3:5", "alias from book"},
+		{"pr 3:5-6", "Prov 3:5–6", "second added alias"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ref, err := bibleref.Parse(tc.input, tbl)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tc.input, err)
+			}
+			if ref.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, ref.String())
+			}
+		})
+	}
+}
+
+// TestTableBuilder_Errors tests that building mistakes and invalid books are reported by Build.
+func TestTableBuilder_Errors(t *testing.T) {
+	gen := bibleref.Book{OSIS: "Gen", Name: "Genesis", Testament: "OT", Order: 1, Chapters: 50}
+
+	testCases := []struct {
+		builder *bibleref.TableBuilder
+		desc    string
+	}{
+		{bibleref.NewTableBuilder().AddAlias("Gen", "gn").AddBook(gen), "alias before book"},
+		{bibleref.NewTableBuilder().AddBook(gen).AddBook(gen), "duplicate book"},
+		{bibleref.NewTableBuilder().AddBook(bibleref.Book{OSIS: "Gen", Name: "Genesis", Order: 1}), "invalid book"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if _, err := tc.builder.Build(); !errors.Is(err, bibleref.ErrInvalidBook) {
+				t.Errorf("expected ErrInvalidBook, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package bibleref
+
+import "strings"
+
+// usfmCodes maps standard OSIS book codes to their USFM (Paratext) equivalents,
+// which are used by services such as YouVersion.
+var usfmCodes = map[string]string{
+	"Gen": "GEN", "Exod": "EXO", "Lev": "LEV", "Num": "NUM", "Deut": "DEU",
+	"Josh": "JOS", "Judg": "JDG", "Ruth": "RUT", "1Sam": "1SA", "2Sam": "2SA",
+	"1Kgs": "1KI", "2Kgs": "2KI", "1Chr": "1CH", "2Chr": "2CH", "Ezra": "EZR",
+	"Neh": "NEH", "Esth": "EST", "Job": "JOB", "Ps": "PSA", "Prov": "PRO",
+	"Eccl": "ECC", "Song": "SNG", "Isa": "ISA", "Jer": "JER", "Lam": "LAM",
+	"Ezek": "EZK", "Dan": "DAN", "Hos": "HOS", "Joel": "JOL", "Amos": "AMO",
+	"Obad": "OBA", "Jonah": "JON", "Mic": "MIC", "Nah": "NAM", "Hab": "HAB",
+	"Zeph": "ZEP", "Hag": "HAG", "Zech": "ZEC", "Mal": "MAL",
+	"Matt": "MAT", "Mark": "MRK", "Luke": "LUK", "John": "JHN", "Acts": "ACT",
+	"Rom": "ROM", "1Cor": "1CO", "2Cor": "2CO", "Gal": "GAL", "Eph": "EPH",
+	"Phil": "PHP", "Col": "COL", "1Thess": "1TH", "2Thess": "2TH", "1Tim": "1TI",
+	"2Tim": "2TI", "Titus": "TIT", "Phlm": "PHM", "Heb": "HEB", "Jas": "JAS",
+	"1Pet": "1PE", "2Pet": "2PE", "1John": "1JN", "2John": "2JN", "3John": "3JN",
+	"Jude": "JUD", "Rev": "REV",
+	"Tob": "TOB", "Jdt": "JDT", "AddEsth": "ESG", "Wis": "WIS", "Sir": "SIR",
+	"Bar": "BAR", "PrAzar": "S3Y", "Sus": "SUS", "Bel": "BEL", "1Macc": "1MA",
+	"2Macc": "2MA", "1Esd": "1ES", "2Esd": "2ES", "PrMan": "MAN",
+}
+
+// standardBooks lists the OSIS codes of the 66 books of the Protestant canon in canonical order.
+var standardBooks = []string{
+	"Gen", "Exod", "Lev", "Num", "Deut", "Josh", "Judg", "Ruth", "1Sam", "2Sam",
+	"1Kgs", "2Kgs", "1Chr", "2Chr", "Ezra", "Neh", "Esth", "Job", "Ps", "Prov",
+	"Eccl", "Song", "Isa", "Jer", "Lam", "Ezek", "Dan", "Hos", "Joel", "Amos",
+	"Obad", "Jonah", "Mic", "Nah", "Hab", "Zeph", "Hag", "Zech", "Mal",
+	"Matt", "Mark", "Luke", "John", "Acts", "Rom", "1Cor", "2Cor", "Gal", "Eph",
+	"Phil", "Col", "1Thess", "2Thess", "1Tim", "2Tim", "Titus", "Phlm", "Heb", "Jas",
+	"1Pet", "2Pet", "1John", "2John", "3John", "Jude", "Rev",
+}
+
+// usfmCode returns the USFM code for an OSIS book code. Spaces are ignored so that
+// datasets using codes like "1 Sam" resolve to the same entry as "1Sam".
+func usfmCode(osis string) (string, bool) {
+	code, ok := usfmCodes[strings.ReplaceAll(osis, " ", "")]
+	return code, ok
+}
@@ -0,0 +1,85 @@
+package bibleref
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"strings"
+
+	"github.com/julianstephens/canonref/util"
+)
+
+// WriteGoSource writes a Go source file in package pkg that declares a variable varName
+// holding a *Table equivalent to t, built with NewTable from the Table's books. The generated
+// file needs no data files or embedding at runtime, so a dataset can be compiled into a binary.
+// Books are written in the order of BooksInOrder so that the output is deterministic, and
+// the source is gofmt-formatted.
+func (t *Table) WriteGoSource(w io.Writer, pkg, varName string) error {
+	if t == nil {
+		return &BibleRefError{
+			Kind:    KindInvalidBook,
+			Err:     ErrInvalidBook,
+			Message: util.Ptr("cannot generate source for a nil table"),
+		}
+	}
+	for _, ident := range []string{pkg, varName} {
+		if !token.IsIdentifier(ident) {
+			return &BibleRefError{
+				Kind:    KindUnsupportedFormat,
+				Err:     ErrUnsupportedFormat,
+				Message: util.Ptr(fmt.Sprintf("invalid Go identifier: %q", ident)),
+			}
+		}
+	}
+
+	books := t.BooksInOrder()
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by canonref; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	fmt.Fprintf(&buf, "import \"github.com/julianstephens/canonref/bibleref\"\n\n")
+	fmt.Fprintf(&buf, "// %s is a Table of %d books.\n", varName, len(books))
+	fmt.Fprintf(&buf, "var %s = func() *bibleref.Table {\n", varName)
+	fmt.Fprintf(&buf, "tbl, err := bibleref.NewTable([]bibleref.Book{\n")
+	for _, book := range books {
+		writeBookLiteral(&buf, book)
+	}
+	fmt.Fprintf(&buf, "})\nif err != nil {\npanic(err)\n}\nreturn tbl\n}()\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// writeBookLiteral writes book as a bibleref.Book composite literal, omitting empty optional
+// fields.
+func writeBookLiteral(buf *bytes.Buffer, book Book) {
+	fmt.Fprintf(buf, "{\nOSIS: %q,\nName: %q,\n", book.OSIS, book.Name)
+	if len(book.Aliases) > 0 {
+		quoted := make([]string, len(book.Aliases))
+		for i, alias := range book.Aliases {
+			quoted[i] = fmt.Sprintf("%q", alias)
+		}
+		fmt.Fprintf(buf, "Aliases: []string{%s},\n", strings.Join(quoted, ", "))
+	}
+	fmt.Fprintf(buf, "Testament: %q,\nOrder: %d,\nChapters: %d,\n", book.Testament, book.Order, book.Chapters)
+	if len(book.VerseCounts) > 0 {
+		counts := make([]string, len(book.VerseCounts))
+		for i, count := range book.VerseCounts {
+			counts[i] = fmt.Sprint(count)
+		}
+		fmt.Fprintf(buf, "VerseCounts: []int{%s},\n", strings.Join(counts, ", "))
+	}
+	if book.HebrewName != "" {
+		fmt.Fprintf(buf, "HebrewName: %q,\n", book.HebrewName)
+	}
+	if book.AllowVerseZero {
+		fmt.Fprintf(buf, "AllowVerseZero: true,\n")
+	}
+	fmt.Fprintf(buf, "},\n")
+}
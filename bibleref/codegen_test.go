@@ -0,0 +1,51 @@
+package bibleref_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/julianstephens/canonref/bibleref"
+)
+
+// TestTable_WriteGoSource tests generating Go source for a Table against a golden file.
+func TestTable_WriteGoSource(t *testing.T) {
+	tbl, err := bibleref.NewTable([]bibleref.Book{
+		{OSIS: "Prov", Name: "Proverbs", Aliases: []string{"proverbs", "prov"}, Testament: "OT", Order: 20, Chapters: 2, VerseCounts: []int{33, 22}, HebrewName: "משלי"},
+		{OSIS: "Gen", Name: "Genesis", Aliases: []string{"genesis"}, Testament: "OT", Order: 1, Chapters: 50},
+		{OSIS: "Jude", Name: "Jude", Testament: "NT", Order: 65, Chapters: 1},
+		{OSIS: "Ps", Name: "Psalms", Testament: "OT", Order: 19, Chapters: 150, AllowVerseZero: true},
+	})
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tbl.WriteGoSource(&buf, "canon", "Books"); err != nil {
+		t.Fatalf("WriteGoSource failed: %v", err)
+	}
+
+	golden, err := os.ReadFile("testdata/table.go.golden")
+	if err != nil {
+		t.Fatalf("reading golden file: %v", err)
+	}
+	if got := buf.String(); got != string(golden) {
+		t.Errorf("generated source does not match golden file:\n%s", got)
+	}
+
+	testCases := []struct {
+		pkg, varName string
+		desc         string
+	}{
+		{"canon", "1Books", "invalid variable name"},
+		{"my-canon", "Books", "invalid package name"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if err := tbl.WriteGoSource(&buf, tc.pkg, tc.varName); !errors.Is(err, bibleref.ErrUnsupportedFormat) {
+				t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+			}
+		})
+	}
+}
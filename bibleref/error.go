@@ -1,6 +1,11 @@
 package bibleref
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/julianstephens/canonref/util"
+)
 
 type ErrKind int
 
@@ -11,6 +16,7 @@ const (
 	KindInvalidChapter
 	KindInvalidVerse
 	KindUnsupportedFormat
+	KindMissingData
 )
 
 var (
@@ -21,8 +27,23 @@ var (
 	ErrInvalidChapter           = fmt.Errorf("invalid chapter")
 	ErrInvalidVerse             = fmt.Errorf("invalid verse")
 	ErrUnsupportedFormat        = fmt.Errorf("unsupported format")
+	ErrVerseCountsUnavailable   = fmt.Errorf("verse counts unavailable")
+	ErrUnexpectedBookToken      = fmt.Errorf("unexpected book token")
+	ErrEndOfCanon               = fmt.Errorf("end of canon")
+	ErrInputTooLong             = fmt.Errorf("input too long")
 )
 
+// kindErrors maps each ErrKind to the sentinel error that describes it.
+var kindErrors = map[ErrKind]error{
+	KindParse:             ErrBibleRefParseFailed,
+	KindUnknownBook:       ErrInvalidOSISCode,
+	KindInvalidBook:       ErrInvalidBook,
+	KindInvalidChapter:    ErrInvalidChapter,
+	KindInvalidVerse:      ErrInvalidVerse,
+	KindUnsupportedFormat: ErrUnsupportedFormat,
+	KindMissingData:       ErrVerseCountsUnavailable,
+}
+
 type BibleRefError struct {
 	Kind    ErrKind
 	Message *string
@@ -30,13 +51,41 @@ type BibleRefError struct {
 	Cause   error
 }
 
+// Error formats the error as "Bible reference error: <message>, err: <err> (cause: <cause>)",
+// omitting the message, err, and cause clauses that are unset.
 func (e *BibleRefError) Error() string {
-	if e.Message != nil {
-		return fmt.Sprintf("Bible reference error: %s, err: %v (cause: %v)", *e.Message, e.Err, e.Cause)
-	}
-	return fmt.Sprintf("Bible reference error: err: %v (cause: %v)", e.Err, e.Cause)
+	return util.FormatError("Bible reference error", e.Message, e.Err, e.Cause)
 }
 
 func (e *BibleRefError) Unwrap() error {
 	return e.Err
 }
+
+// Is reports whether target is the sentinel error for the error's Kind, e.g. ErrInvalidChapter
+// for KindInvalidChapter, or matches its Cause. This lets errors.Is see through the
+// KindParse wrapper that Parse puts around the underlying failure.
+func (e *BibleRefError) Is(target error) bool {
+	if kindErrors[e.Kind] == target {
+		return true
+	}
+	return e.Cause != nil && errors.Is(e.Cause, target)
+}
+
+// KindOf returns the Kind of the innermost BibleRefError in err's chain, following both
+// wrapped errors and Cause. For a Parse failure this is the Kind of the underlying cause,
+// such as KindInvalidChapter, rather than the KindParse of the wrapper. It returns false if
+// err contains no BibleRefError.
+func KindOf(err error) (ErrKind, bool) {
+	var refErr *BibleRefError
+	if !errors.As(err, &refErr) {
+		return 0, false
+	}
+	for refErr.Cause != nil {
+		var inner *BibleRefError
+		if !errors.As(refErr.Cause, &inner) {
+			break
+		}
+		refErr = inner
+	}
+	return refErr.Kind, true
+}
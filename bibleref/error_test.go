@@ -0,0 +1,83 @@
+package bibleref_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/julianstephens/canonref/bibleref"
+	"github.com/julianstephens/canonref/util"
+)
+
+// TestBibleRefError_Is tests matching parse errors against the sentinels for their kinds and
+// inspecting the kind of the underlying failure.
+func TestBibleRefError_Is(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		sentinel error
+		other    error
+		kind     bibleref.ErrKind
+		desc     string
+	}{
+		{"Gen 51:1", bibleref.ErrInvalidChapter, bibleref.ErrInvalidVerse, bibleref.KindInvalidChapter, "chapter out of range"},
+		{"Prov 3:0", bibleref.ErrInvalidVerse, bibleref.ErrInvalidChapter, bibleref.KindInvalidVerse, "invalid verse"},
+		{"Nope 1:1", bibleref.ErrInvalidOSISCode, bibleref.ErrInvalidChapter, bibleref.KindUnknownBook, "unknown book"},
+		{"Gen", bibleref.ErrBibleRefParseFailed, bibleref.ErrInvalidVerse, bibleref.KindParse, "malformed"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := bibleref.Parse(tc.input, tbl)
+			if !errors.Is(err, tc.sentinel) {
+				t.Errorf("expected errors.Is(%v, %v)", err, tc.sentinel)
+			}
+			if !errors.Is(err, bibleref.ErrBibleRefParseFailed) {
+				t.Errorf("expected errors.Is(%v, ErrBibleRefParseFailed)", err)
+			}
+			if errors.Is(err, tc.other) {
+				t.Errorf("unexpected errors.Is(%v, %v)", err, tc.other)
+			}
+			wrapped := fmt.Errorf("loading notes: %w", err)
+			if kind, ok := bibleref.KindOf(wrapped); !ok || kind != tc.kind {
+				t.Errorf("expected kind %v, got %v (ok=%v)", tc.kind, kind, ok)
+			}
+		})
+	}
+
+	kindOnly := &bibleref.BibleRefError{Kind: bibleref.KindMissingData}
+	if !errors.Is(kindOnly, bibleref.ErrVerseCountsUnavailable) {
+		t.Error("expected an error without Err to match the sentinel for its kind")
+	}
+	if _, ok := bibleref.KindOf(errors.New("plain")); ok {
+		t.Error("expected no kind for an error without a BibleRefError")
+	}
+}
+
+// TestBibleRefError_Error tests the formatted message with and without each optional clause.
+func TestBibleRefError_Error(t *testing.T) {
+	cause := errors.New("boom")
+	testCases := []struct {
+		err      *bibleref.BibleRefError
+		expected string
+		desc     string
+	}{
+		{&bibleref.BibleRefError{Err: bibleref.ErrInvalidVerse, Message: util.Ptr("bad verse")}, "Bible reference error: bad verse, err: invalid verse", "message without cause"},
+		{&bibleref.BibleRefError{Err: bibleref.ErrInvalidVerse, Message: util.Ptr("bad verse"), Cause: cause}, "Bible reference error: bad verse, err: invalid verse (cause: boom)", "message with cause"},
+		{&bibleref.BibleRefError{Err: bibleref.ErrInvalidOSISCode}, "Bible reference error: err: invalid OSIS code", "no message"},
+		{&bibleref.BibleRefError{Message: util.Ptr("bad verse")}, "Bible reference error: bad verse", "no err"},
+		{&bibleref.BibleRefError{Cause: cause}, "Bible reference error (cause: boom)", "cause only"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.err.Error(); got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package bibleref
+
+import (
+	"regexp"
+	"strings"
+)
+
+// maxBookWords is the most words a book name may span in free text, as in "song of songs".
+const maxBookWords = 3
+
+var (
+	wordPattern = regexp.MustCompile(`[\p{L}\p{N}]+\.?`)
+	tailPattern = regexp.MustCompile(`^\s+\d+(?::\d+)?(?:[-\x{2013}]\d+(?::\d+)?)?(?:,\s*\d+(?:[-\x{2013}]\d+)?)*`)
+)
+
+// RefSpan is the location of a candidate reference in free text, as byte offsets into the
+// text. Start is inclusive and End exclusive, so text[Start:End] is the reference.
+type RefSpan struct {
+	Start int
+	End   int
+}
+
+// FindRefSpans scans free text for candidate references and returns their locations in order.
+// A candidate is a book name known to the Table (up to three words, with an optional trailing
+// period) followed by a chapter and an optional verse, range, or verse list. Surrounding
+// punctuation such as parentheses, sentence periods, and semicolons is not included. The
+// spans are candidates only: a span may still fail to parse, for example when the chapter is
+// out of range.
+func FindRefSpans(text string, tbl *Table) []RefSpan {
+	if tbl == nil {
+		return nil
+	}
+
+	words := wordPattern.FindAllStringIndex(text, -1)
+	var spans []RefSpan
+	for i := 0; i < len(words); i++ {
+		span, ok := refSpanAt(text, words[i:], tbl)
+		if !ok {
+			continue
+		}
+		spans = append(spans, span)
+		for i+1 < len(words) && words[i+1][0] < span.End {
+			i++
+		}
+	}
+	return spans
+}
+
+// refSpanAt matches a reference starting at the first of words, trying the longest book name
+// first.
+func refSpanAt(text string, words [][]int, tbl *Table) (RefSpan, bool) {
+	for n := min(maxBookWords, len(words)); n > 0; n-- {
+		start, end := words[0][0], words[n-1][1]
+		if !joinedBySpace(text, words[:n]) {
+			continue
+		}
+		if _, ok := tbl.Lookup(text[start:end]); !ok {
+			continue
+		}
+		tail := tailPattern.FindStringIndex(text[end:])
+		if tail == nil {
+			continue
+		}
+		return RefSpan{Start: start, End: end + tail[1]}, true
+	}
+	return RefSpan{}, false
+}
+
+// joinedBySpace reports whether consecutive words are separated only by whitespace.
+func joinedBySpace(text string, words [][]int) bool {
+	for i := 1; i < len(words); i++ {
+		gap := text[words[i-1][1]:words[i][0]]
+		if gap == "" || strings.TrimSpace(gap) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseMany finds the references in free text with FindRefSpans and parses each one. It returns
+// the references that parsed successfully, in the order they appear, and the errors for the
+// spans that did not.
+func ParseMany(text string, tbl *Table) ([]*BibleRef, []error) {
+	var refs []*BibleRef
+	var errs []error
+	for _, span := range FindRefSpans(text, tbl) {
+		ref, err := Parse(text[span.Start:span.End], tbl)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		refs = append(refs, ref)
+	}
+	return refs, errs
+}
@@ -0,0 +1,75 @@
+package bibleref_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/julianstephens/canonref/bibleref"
+)
+
+// TestFindRefSpans tests locating references in prose around parentheses and punctuation.
+func TestFindRefSpans(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		text     string
+		expected []string
+		desc     string
+	}{
+		{"see Gen 1:1 and also Prov 3:5-6 (cf. Matt 5).", []string{"Gen 1:1", "Prov 3:5-6", "Matt 5"}, "sermon notes"},
+		{"Read Prov. 3:5, 7; then pray.", []string{"Prov. 3:5, 7"}, "abbreviation and verse list"},
+		{"As in 1 Sam 3:4-4:2, the call comes at night.", []string{"1 Sam 3:4-4:2"}, "numbered book and cross-chapter range"},
+		{"(Genesis 1)", []string{"Genesis 1"}, "parenthetical"},
+		{"Genesis tells of creation.", nil, "book without chapter"},
+		{"The 3 kings met.", nil, "no book"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			spans := bibleref.FindRefSpans(tc.text, tbl)
+			if len(spans) != len(tc.expected) {
+				t.Fatalf("expected %d spans, got %d: %v", len(tc.expected), len(spans), spans)
+			}
+			for i, span := range spans {
+				if got := tc.text[span.Start:span.End]; got != tc.expected[i] {
+					t.Errorf("span %d: expected %q, got %q", i, tc.expected[i], got)
+				}
+			}
+		})
+	}
+}
+
+// TestParseMany tests extracting parsed references from prose and reporting spans that fail.
+func TestParseMany(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	refs, errs := bibleref.ParseMany("see Gen 1:1 and also Prov 3:5-6 (cf. Matt 5), not Gen 51:1.", tbl)
+	if len(errs) != 1 {
+		t.Errorf("expected 1 error, got %v", errs)
+	}
+
+	expected := []string{"Gen 1:1", "Prov 3:5–6", "Matt 5"}
+	if len(refs) != len(expected) {
+		t.Fatalf("expected %d refs, got %d", len(expected), len(refs))
+	}
+	for i, want := range expected {
+		if refs[i].String() != want {
+			t.Errorf("ref %d: expected %q, got %q", i, want, refs[i].String())
+		}
+	}
+}
+
+func BenchmarkParseMany(b *testing.B) {
+	tbl, _ := bibleref.NewTable(testBooks())
+	text := strings.Repeat("In the beginning was the word, as the writer of Prov 3:5-6 puts it. ", 50)
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = bibleref.ParseMany(text, tbl)
+	}
+}
@@ -0,0 +1,280 @@
+package bibleref
+
+import (
+	"cmp"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/julianstephens/canonref/util"
+)
+
+// BookNameStyle selects how FormatWith renders the book of a reference.
+type BookNameStyle int
+
+const (
+	BookNameOSIS      BookNameStyle = iota // "Prov"
+	BookNameFull                           // "Proverbs"
+	BookNameUpperOSIS                      // "PROV"
+	BookNameHebrew                         // "משלי", or the full name for books without a HebrewName
+)
+
+// RefTemplate describes a citation layout for FormatWith. Empty separators take the canonical
+// defaults: a space between book and chapter, a colon between chapter and verse, and an en
+// dash in ranges. For example, {Book: BookNameFull, ChapterVerseSeparator: "."} renders
+// "Proverbs 31.10–31". JoinBook writes the chapter directly after the book, ignoring
+// BookSeparator, as in the compact "Prov3:5", which Parse reads back as an attached chapter.
+type RefTemplate struct {
+	Book                  BookNameStyle
+	BookSeparator         string
+	JoinBook              bool
+	ChapterVerseSeparator string
+	RangeSeparator        string
+}
+
+// formatTemplates holds the templates behind the Format values that FormatWith can render.
+var formatTemplates = map[Format]RefTemplate{
+	FormatOSIS:      {Book: BookNameOSIS, BookSeparator: ".", ChapterVerseSeparator: "."},
+	FormatHuman:     {Book: BookNameFull},
+	FormatCanonical: {Book: BookNameOSIS},
+	FormatCompact:   {Book: BookNameOSIS, JoinBook: true},
+}
+
+// FormatWith returns the BibleRef rendered with the layout described by tmpl. The Table is
+// needed only for the full and Hebrew book names; a reference whose book is not in the
+// Table renders with an empty name in those styles.
+func (r BibleRef) FormatWith(tmpl RefTemplate, tbl *Table) string {
+	cv := r.chapterVerse(cmp.Or(tmpl.ChapterVerseSeparator, ":"))
+	if tmpl.RangeSeparator != "" {
+		cv = strings.ReplaceAll(cv, util.EnDash, tmpl.RangeSeparator)
+	}
+	sep := cmp.Or(tmpl.BookSeparator, " ")
+	if tmpl.JoinBook {
+		sep = ""
+	}
+	return r.bookName(tmpl.Book, tbl) + sep + cv
+}
+
+// bookName returns the name of the BibleRef's book in the given style.
+func (r BibleRef) bookName(style BookNameStyle, tbl *Table) string {
+	switch style {
+	case BookNameFull:
+		book, _ := tbl.Book(r.OSIS)
+		return book.Name
+	case BookNameUpperOSIS:
+		return strings.ToUpper(r.OSIS)
+	case BookNameHebrew:
+		book, _ := tbl.Book(r.OSIS)
+		return cmp.Or(book.HebrewName, book.Name)
+	default:
+		return r.OSIS
+	}
+}
+
+// FormatAsTyped returns the BibleRef with the book as it was typed and the chapter and verse
+// normalized, e.g. "mt 5:3–5" for "mt 5:3-5", when it was parsed with WithRetainedAlias.
+// Otherwise, as for a reference built directly, it returns the canonical form from String.
+func (r BibleRef) FormatAsTyped() string {
+	if r.typedBook == "" {
+		return r.String()
+	}
+	return r.typedBook + " " + r.chapterVerse(":")
+}
+
+// FormatSpoken returns the BibleRef spelled out for screen readers and speech synthesis, with
+// the full book name and the words "chapter", "verse", and "to", e.g. "Proverbs chapter 31
+// verses 10 to 31" or "Genesis chapter 1 verse 30 to chapter 2 verse 3". A verse list is read
+// as "verses 5, 7 and 9 to 11", and the "ff" notation as "and following". A book that is not
+// in the Table is named by its OSIS code.
+func (r BibleRef) FormatSpoken(tbl *Table) string {
+	book, _ := tbl.Book(r.OSIS)
+	name := cmp.Or(book.Name, r.OSIS)
+	switch {
+	case r.Verse == nil && r.EndChapter != nil:
+		return fmt.Sprintf("%s chapters %d to %d", name, r.Chapter, *r.EndChapter)
+	case r.Verse == nil:
+		return fmt.Sprintf("%s chapter %d", name, r.Chapter)
+	case r.EndChapter != nil && r.Verse.EndVerse != nil:
+		return fmt.Sprintf("%s chapter %d verse %d%s to chapter %d verse %d%s", name, r.Chapter, r.Verse.StartVerse, r.Verse.StartPart, *r.EndChapter, *r.Verse.EndVerse, r.Verse.EndPart)
+	}
+
+	verses := append([]util.VerseRange{*r.Verse}, r.Additional...)
+	spoken := make([]string, len(verses))
+	for i, v := range verses {
+		spoken[i] = spokenVerses(v)
+	}
+	word := util.If(r.IsSingleVerse(), "verse", "verses")
+	list := spoken[len(spoken)-1]
+	if len(spoken) > 1 {
+		list = strings.Join(spoken[:len(spoken)-1], ", ") + " and " + list
+	}
+	return fmt.Sprintf("%s chapter %d %s %s", name, r.Chapter, word, list)
+}
+
+// spokenVerses returns a verse range as FormatSpoken reads it, e.g. "10 to 31" or "5 and
+// following".
+func spokenVerses(v util.VerseRange) string {
+	start := strconv.Itoa(v.StartVerse) + v.StartPart
+	switch {
+	case v.Following != "":
+		return start + " and following"
+	case v.EndVerse == nil:
+		return start
+	default:
+		return fmt.Sprintf("%s to %d%s", start, *v.EndVerse, v.EndPart)
+	}
+}
+
+// FormatWithCount returns the canonical representation of the BibleRef followed by the
+// number of verses it covers, e.g. "Prov 31:10–31 (22 verses)".
+// It returns an error if the count cannot be determined (see VerseCount).
+func (r BibleRef) FormatWithCount(tbl *Table) (string, error) {
+	count, err := r.VerseCount(tbl)
+	if err != nil {
+		return "", err
+	}
+
+	noun := "verses"
+	if count == 1 {
+		noun = "verse"
+	}
+
+	return fmt.Sprintf("%s (%d %s)", r.String(), count, noun), nil
+}
+
+// Services supported by FormatServiceURL.
+const (
+	ServiceYouVersion   = "youversion"   // "PRO.31.10-31"
+	ServiceBibleGateway = "biblegateway" // "Prov+31%3A10-31"
+)
+
+// FormatServiceURL returns the BibleRef in the form expected by the URL of a popular
+// Bible website, ready to be appended to the service's passage URL.
+// For ServiceYouVersion, the book is rendered as its USFM code with period separators.
+// For ServiceBibleGateway, the canonical reference is encoded as a search query value.
+// Ranges always use an ASCII hyphen. It returns an error for unknown services or books
+// that have no code for the service.
+func (r BibleRef) FormatServiceURL(service string) (string, error) {
+	switch strings.ToLower(service) {
+	case ServiceYouVersion:
+		code, ok := usfmCode(r.OSIS)
+		if !ok {
+			return "", &BibleRefError{
+				Kind:    KindUnsupportedFormat,
+				Err:     ErrUnsupportedFormat,
+				Message: util.Ptr(fmt.Sprintf("no %s book code for OSIS code: %s", service, r.OSIS)),
+			}
+		}
+		return fmt.Sprintf("%s.%s", code, asciiRange(r.chapterVerse("."))), nil
+	case ServiceBibleGateway:
+		ref := fmt.Sprintf("%s %s", r.OSIS, asciiRange(r.chapterVerse(":")))
+		return url.QueryEscape(ref), nil
+	default:
+		return "", &BibleRefError{
+			Kind:    KindUnsupportedFormat,
+			Err:     ErrUnsupportedFormat,
+			Message: util.Ptr(fmt.Sprintf("unsupported service: %s", service)),
+		}
+	}
+}
+
+// MarkdownLinkPlaceholder marks where FormatMarkdown inserts the reference in a link template.
+const MarkdownLinkPlaceholder = "{ref}"
+
+// markdownEscaper escapes the characters that Markdown treats as inline syntax.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`,
+	"(", `\(`, ")", `\)`, "<", `\<`, ">", `\>`, "#", `\#`, "|", `\|`, "~", `\~`,
+)
+
+// FormatMarkdown returns the canonical representation of the BibleRef as Markdown. Without a
+// link template the reference is bold, e.g. "**Prov 31:10–31**". Otherwise it is a link
+// whose target is linkTemplate with MarkdownLinkPlaceholder replaced by the query-escaped
+// ASCII reference, so "https://example.com/?q={ref}" gives
+// "[Prov 31:10–31](https://example.com/?q=Prov+31%3A10-31)". Markdown syntax characters in
+// the book are escaped. The OSIS code is used as written, so tbl may be nil.
+func (r BibleRef) FormatMarkdown(tbl *Table, linkTemplate string) string {
+	text := markdownEscaper.Replace(r.OSIS) + " " + r.chapterVerse(":")
+	if linkTemplate == "" {
+		return "**" + text + "**"
+	}
+	query := url.QueryEscape(fmt.Sprintf("%s %s", r.OSIS, asciiRange(r.chapterVerse(":"))))
+	return fmt.Sprintf("[%s](%s)", text, strings.ReplaceAll(linkTemplate, MarkdownLinkPlaceholder, query))
+}
+
+// FormatFilename returns a file name for the BibleRef whose lexical order matches canonical
+// order, e.g. "40-001-001-matt-1-1" for Matt 1:1. It joins the book's Order, the zero-padded
+// chapter and start verse, and a lowercase slug of the canonical reference. Chapter-only
+// references use verse "000" so that they sort before the verses of the chapter. Callers
+// append their own extension. It returns an error if the OSIS code is not in the Table.
+func (r BibleRef) FormatFilename(tbl *Table) (string, error) {
+	book, err := r.book(tbl)
+	if err != nil {
+		return "", err
+	}
+
+	verse := 0
+	if r.Verse != nil {
+		verse = r.Verse.StartVerse
+	}
+
+	slug := slugify(fmt.Sprintf("%s %s", book.OSIS, r.chapterVerse(":")))
+	return fmt.Sprintf("%02d-%03d-%03d-%s", book.Order, r.Chapter, verse, slug), nil
+}
+
+// slugify lowercases s and replaces each run of characters other than letters and digits
+// with a single hyphen, trimming hyphens from both ends.
+func slugify(s string) string {
+	var b strings.Builder
+	pending := false
+	for _, c := range strings.ToLower(s) {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+			pending = b.Len() > 0
+			continue
+		}
+		if pending {
+			b.WriteString(util.Hyphen)
+			pending = false
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// CanonicalASCII returns the canonical representation of the BibleRef using only ASCII bytes,
+// e.g. "Prov 31:10-31", for interchange with systems that reject other input. Ranges use an
+// ASCII hyphen. It returns an error if the OSIS code is not in the Table or contains non-ASCII
+// characters.
+func (r BibleRef) CanonicalASCII(tbl *Table) (string, error) {
+	book, err := r.book(tbl)
+	if err != nil {
+		return "", err
+	}
+
+	if !isASCII(book.OSIS) {
+		return "", &BibleRefError{
+			Kind:    KindUnsupportedFormat,
+			Err:     ErrUnsupportedFormat,
+			Message: util.Ptr(fmt.Sprintf("OSIS code is not ASCII: %q", book.OSIS)),
+		}
+	}
+
+	return fmt.Sprintf("%s %s", book.OSIS, asciiRange(r.chapterVerse(":"))), nil
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// asciiRange replaces the en dashes in a formatted chapter and verse string with ASCII hyphens.
+func asciiRange(s string) string {
+	return strings.ReplaceAll(s, util.EnDash, util.Hyphen)
+}
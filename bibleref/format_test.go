@@ -0,0 +1,448 @@
+package bibleref_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/julianstephens/canonref/bibleref"
+)
+
+// TestFormatWithCount tests that the verse count is appended to the canonical form,
+// and that chapter-only references and "ff" ranges require verse data.
+func TestFormatWithCount(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected string
+		desc     string
+	}{
+		{"Prov 31:10-31", "Prov 31:10–31 (22 verses)", "explicit range"},
+		{"Prov 3:5", "Prov 3:5 (1 verse)", "single verse"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := bibleref.MustParse(tc.input, tbl).FormatWithCount(tbl)
+			if err != nil {
+				t.Fatalf("FormatWithCount failed: %v", err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+
+	t.Run("chapter-only without verse counts", func(t *testing.T) {
+		_, err := bibleref.MustParse("Prov 31", tbl).FormatWithCount(tbl)
+		if !errors.Is(err, bibleref.ErrVerseCountsUnavailable) {
+			t.Errorf("expected ErrVerseCountsUnavailable, got %v", err)
+		}
+	})
+
+	t.Run("ff without verse counts", func(t *testing.T) {
+		_, err := bibleref.MustParse("Matt 5:3ff", tbl).FormatWithCount(tbl)
+		if !errors.Is(err, bibleref.ErrVerseCountsUnavailable) {
+			t.Errorf("expected ErrVerseCountsUnavailable, got %v", err)
+		}
+	})
+
+	t.Run("chapter-only with verse counts", func(t *testing.T) {
+		counted, err := bibleref.NewTable([]bibleref.Book{
+			{OSIS: "Jude", Name: "Jude", Testament: "NT", Order: 65, Chapters: 1, VerseCounts: []int{25}},
+		})
+		if err != nil {
+			t.Fatalf("NewTable failed: %v", err)
+		}
+		got, err := bibleref.MustParse("Jude 1", counted).FormatWithCount(counted)
+		if err != nil {
+			t.Fatalf("FormatWithCount failed: %v", err)
+		}
+		if got != "Jude 1 (25 verses)" {
+			t.Errorf("expected %q, got %q", "Jude 1 (25 verses)", got)
+		}
+		got, err = bibleref.MustParse("Jude 1:20ff", counted).FormatWithCount(counted)
+		if err != nil {
+			t.Fatalf("FormatWithCount failed: %v", err)
+		}
+		if got != "Jude 1:20ff (6 verses)" {
+			t.Errorf("expected %q, got %q", "Jude 1:20ff (6 verses)", got)
+		}
+	})
+}
+
+// TestFormatServiceURL tests the URL fragments produced for each supported service.
+func TestFormatServiceURL(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		service  string
+		expected string
+		desc     string
+	}{
+		{"Prov 31:10-31", bibleref.ServiceYouVersion, "PRO.31.10-31", "YouVersion range"},
+		{"Matt 5", bibleref.ServiceYouVersion, "MAT.5", "YouVersion chapter-only"},
+		{"1 Samuel 3:1", bibleref.ServiceYouVersion, "1SA.3.1", "YouVersion digit-prefixed book"},
+		{"Prov 31:10-31", bibleref.ServiceBibleGateway, "Prov+31%3A10-31", "BibleGateway range"},
+		{"Matt 5", bibleref.ServiceBibleGateway, "Matt+5", "BibleGateway chapter-only"},
+		{"Gen 1:30-2:3", bibleref.ServiceYouVersion, "GEN.1.30-2.3", "YouVersion cross-chapter"},
+		{"Gen 1:30-2:3", bibleref.ServiceBibleGateway, "Gen+1%3A30-2%3A3", "BibleGateway cross-chapter"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := bibleref.MustParse(tc.input, tbl).FormatServiceURL(tc.service)
+			if err != nil {
+				t.Fatalf("FormatServiceURL failed: %v", err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+
+	t.Run("unknown service", func(t *testing.T) {
+		_, err := bibleref.MustParse("Prov 3:5", tbl).FormatServiceURL("example")
+		if !errors.Is(err, bibleref.ErrUnsupportedFormat) {
+			t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+		}
+	})
+}
+
+// TestCanonicalASCII tests that the ASCII canonical form uses hyphens for ranges and rejects
+// OSIS codes with non-ASCII characters.
+func TestCanonicalASCII(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	got, err := bibleref.MustParse("Proverbs 31:10-31", tbl).CanonicalASCII(tbl)
+	if err != nil {
+		t.Fatalf("CanonicalASCII failed: %v", err)
+	}
+	if got != "Prov 31:10-31" {
+		t.Errorf("expected %q, got %q", "Prov 31:10-31", got)
+	}
+
+	t.Run("non-ASCII OSIS", func(t *testing.T) {
+		custom, err := bibleref.NewTable([]bibleref.Book{
+			{OSIS: "Sprü", Name: "Sprüche", Aliases: []string{"sprueche"}, Testament: "OT", Order: 20, Chapters: 31},
+		})
+		if err != nil {
+			t.Fatalf("NewTable failed: %v", err)
+		}
+		_, err = bibleref.MustParse("Sprueche 3:5", custom).CanonicalASCII(custom)
+		if !errors.Is(err, bibleref.ErrUnsupportedFormat) {
+			t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+		}
+	})
+
+	t.Run("unknown OSIS", func(t *testing.T) {
+		_, err := bibleref.BibleRef{OSIS: "Nope", Chapter: 1}.CanonicalASCII(tbl)
+		if !errors.Is(err, bibleref.ErrInvalidOSISCode) {
+			t.Errorf("expected ErrInvalidOSISCode, got %v", err)
+		}
+	})
+}
+
+// TestFormatFilename tests the sortable file name format and that lexical order of the names
+// matches canonical order.
+func TestFormatFilename(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"Matt 1:1", "40-001-001-matt-1-1"},
+		{"Prov 31:10-31", "20-031-010-prov-31-10-31"},
+		{"Ps 119", "19-119-000-ps-119"},
+		{"1 Samuel 3:1", "09-003-001-1sam-3-1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := bibleref.MustParse(tc.input, tbl).FormatFilename(tbl)
+			if err != nil {
+				t.Fatalf("FormatFilename failed: %v", err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+
+	// canonical order: by book order, then chapter, then verse
+	ordered := []string{"Gen 1", "Gen 1:2", "Gen 1:10", "Gen 2:1", "Gen 10:1", "1Sam 1:1", "Ps 9", "Ps 100:1", "Prov 3:5", "Matt 1:1", "Jude 1:3"}
+	names := make([]string, len(ordered))
+	for i, input := range ordered {
+		names[i], err = bibleref.MustParse(input, tbl).FormatFilename(tbl)
+		if err != nil {
+			t.Fatalf("FormatFilename(%q) failed: %v", input, err)
+		}
+	}
+	if !slices.IsSorted(names) {
+		t.Errorf("expected file names in canonical order, got %v", names)
+	}
+
+	if _, err := (bibleref.BibleRef{OSIS: "Nope", Chapter: 1}).FormatFilename(tbl); !errors.Is(err, bibleref.ErrInvalidOSISCode) {
+		t.Errorf("expected ErrInvalidOSISCode, got %v", err)
+	}
+}
+
+// TestFormat_Hebrew tests right-to-left Hebrew rendering with Arabic and Hebrew numerals.
+func TestFormat_Hebrew(t *testing.T) {
+	tbl, err := bibleref.NewTable([]bibleref.Book{
+		{OSIS: "Gen", Name: "Genesis", Aliases: []string{"genesis"}, Testament: "OT", Order: 1, Chapters: 50, HebrewName: "בראשית"},
+		{OSIS: "Ps", Name: "Psalms", Aliases: []string{"psalms"}, Testament: "OT", Order: 19, Chapters: 150, HebrewName: "תהלים"},
+		{OSIS: "Prov", Name: "Proverbs", Aliases: []string{"proverbs"}, Testament: "OT", Order: 20, Chapters: 31, HebrewName: "משלי"},
+		{OSIS: "Matt", Name: "Matthew", Aliases: []string{"matthew"}, Testament: "NT", Order: 40, Chapters: 28},
+	})
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		format   bibleref.Format
+		expected string
+		desc     string
+	}{
+		{"Prov 31:10-31", bibleref.FormatHebrew, "\u2067משלי 31:10–31\u2069", "Arabic numerals"},
+		{"Prov 31:10-31", bibleref.FormatHebrewNumerals, "\u2067משלי ל״א:י׳–ל״א\u2069", "Hebrew numeral range"},
+		{"Gen 1", bibleref.FormatHebrewNumerals, "\u2067בראשית א׳\u2069", "single letter numeral"},
+		{"Ps 119:15", bibleref.FormatHebrewNumerals, "\u2067תהלים קי״ט:ט״ו\u2069", "15 written as 9+6"},
+		{"Ps 150:16", bibleref.FormatHebrewNumerals, "\u2067תהלים ק״נ:ט״ז\u2069", "16 written as 9+7"},
+		{"Matt 5:3", bibleref.FormatHebrew, "\u2067Matthew 5:3\u2069", "falls back to Name"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := bibleref.MustParse(tc.input, tbl).Format(tc.format, tbl)
+			if got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+// TestBibleRef_FormatWith tests rendering with user-supplied templates and the templates
+// behind the fixed formats.
+func TestBibleRef_FormatWith(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		tmpl     bibleref.RefTemplate
+		expected string
+		desc     string
+	}{
+		{"Prov 31:10-31", bibleref.RefTemplate{Book: bibleref.BookNameOSIS, BookSeparator: ".", ChapterVerseSeparator: ".", RangeSeparator: "-"}, "Prov.31.10-31", "period-separated OSIS"},
+		{"Prov 31:10-31", bibleref.RefTemplate{Book: bibleref.BookNameFull}, "Proverbs 31:10–31", "full name with colon"},
+		{"Prov 31:10", bibleref.RefTemplate{Book: bibleref.BookNameFull, ChapterVerseSeparator: "."}, "Proverbs 31.10", "full name with period"},
+		{"Prov 31:10", bibleref.RefTemplate{Book: bibleref.BookNameUpperOSIS}, "PROV 31:10", "uppercase OSIS"},
+		{"Gen 1:31-2:3", bibleref.RefTemplate{Book: bibleref.BookNameFull, ChapterVerseSeparator: ".", RangeSeparator: " - "}, "Genesis 1.31 - 2.3", "cross-chapter range"},
+		{"Prov 3:5,7-9", bibleref.RefTemplate{RangeSeparator: "-"}, "Prov 3:5,7-9", "verse list"},
+		{"Prov 3", bibleref.RefTemplate{Book: bibleref.BookNameFull, ChapterVerseSeparator: "."}, "Proverbs 3", "chapter-only"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := bibleref.MustParse(tc.input, tbl).FormatWith(tc.tmpl, tbl); got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+
+	ref := bibleref.MustParse("Prov 31:10-31", tbl)
+	formats := []struct {
+		format   bibleref.Format
+		expected string
+	}{
+		{bibleref.FormatOSIS, "Prov.31.10–31"},
+		{bibleref.FormatHuman, "Proverbs 31:10–31"},
+		{bibleref.FormatCanonical, "Prov 31:10–31"},
+	}
+	for _, f := range formats {
+		if got := ref.Format(f.format, tbl); got != f.expected {
+			t.Errorf("Format(%d): expected %q, got %q", f.format, f.expected, got)
+		}
+	}
+}
+
+// TestFormat_Compact tests output without a space after the book and parsing it back.
+func TestFormat_Compact(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"Prov 3:5", "Prov3:5"},
+		{"Prov 31:10-31", "Prov31:10–31"},
+		{"1 Sam 3:4", "1Sam3:4"},
+		{"Gen 1:31-2:3", "Gen1:31–2:3"},
+		{"Prov 3:5,7", "Prov3:5,7"},
+		{"Gen 1-3", "Gen1–3"},
+		{"Prov 3", "Prov3"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			ref := bibleref.MustParse(tc.input, tbl)
+			got := ref.Format(bibleref.FormatCompact, tbl)
+			if got != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, got)
+			}
+			reparsed, err := bibleref.Parse(got, tbl)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", got, err)
+			}
+			if !reparsed.Equal(*ref) {
+				t.Errorf("expected %q to parse back to %v, got %v", got, ref, reparsed)
+			}
+		})
+	}
+
+	full := bibleref.RefTemplate{Book: bibleref.BookNameFull, BookSeparator: "_", JoinBook: true}
+	if got := bibleref.MustParse("Prov 3:5", tbl).FormatWith(full, tbl); got != "Proverbs3:5" {
+		t.Errorf("expected JoinBook to override BookSeparator, got %q", got)
+	}
+}
+
+// TestBibleRef_FormatMarkdown tests bold and linked Markdown output and the escaping of
+// Markdown syntax in book codes.
+func TestBibleRef_FormatMarkdown(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	const link = "https://example.com/passage?search={ref}"
+	testCases := []struct {
+		ref      bibleref.BibleRef
+		link     string
+		expected string
+		desc     string
+	}{
+		{*bibleref.MustParse("Prov 31:10-31", tbl), "", "**Prov 31:10–31**", "bold range"},
+		{*bibleref.MustParse("Matt 5", tbl), "", "**Matt 5**", "bold chapter-only"},
+		{*bibleref.MustParse("Prov 31:10-31", tbl), link, "[Prov 31:10–31](https://example.com/passage?search=Prov+31%3A10-31)", "linked range"},
+		{*bibleref.MustParse("Gen 1:30-2:3", tbl), link, "[Gen 1:30–2:3](https://example.com/passage?search=Gen+1%3A30-2%3A3)", "linked cross-chapter"},
+		{bibleref.BibleRef{OSIS: "Odes_Sol*", Chapter: 1}, "", `**Odes\_Sol\* 1**`, "escaped book"},
+		{bibleref.BibleRef{OSIS: "Add[Esth]", Chapter: 2}, link, `[Add\[Esth\] 2](https://example.com/passage?search=Add%5BEsth%5D+2)`, "escaped linked book"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.ref.FormatMarkdown(tbl, tc.link); got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+// TestBibleRef_FormatAsTyped tests that the typed book is kept only when requested, and that
+// references without one fall back to the canonical form.
+func TestBibleRef_FormatAsTyped(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		retain   bool
+		expected string
+		alias    string
+		desc     string
+	}{
+		{"mt 5", false, "Matt 5", "", "not retained"},
+		{"mt 5", true, "mt 5", "mt", "retained abbreviation"},
+		{"MATTHEW 5:3-5", true, "MATTHEW 5:3–5", "matthew", "retained casing"},
+		{"Pro. 3:5", true, "Pro. 3:5", "pro", "retained punctuation"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var opts []bibleref.ParseOption
+			if tc.retain {
+				opts = append(opts, bibleref.WithRetainedAlias())
+			}
+			ref, err := bibleref.Parse(tc.input, tbl, opts...)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tc.input, err)
+			}
+			if got := ref.FormatAsTyped(); got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+			if got := ref.MatchedAlias(); got != tc.alias {
+				t.Errorf("expected MatchedAlias %q, got %q", tc.alias, got)
+			}
+			if plain := bibleref.MustParse(tc.input, tbl); !ref.Equal(*plain) || ref.String() != plain.String() {
+				t.Errorf("expected %v to equal %v regardless of the retained alias", ref, plain)
+			}
+		})
+	}
+
+	built := bibleref.BibleRef{OSIS: "Matt", Chapter: 5}
+	if got := built.FormatAsTyped(); got != "Matt 5" {
+		t.Errorf("expected canonical fallback %q, got %q", "Matt 5", got)
+	}
+}
+
+// TestBibleRef_FormatSpoken tests the speech-friendly rendering of each reference shape.
+func TestBibleRef_FormatSpoken(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected string
+		desc     string
+	}{
+		{"Prov 31", "Proverbs chapter 31", "chapter only"},
+		{"Gen 1-3", "Genesis chapters 1 to 3", "chapter range"},
+		{"Prov 31:10", "Proverbs chapter 31 verse 10", "single verse"},
+		{"Prov 31:10-31", "Proverbs chapter 31 verses 10 to 31", "verse range"},
+		{"Gen 1:30-2:3", "Genesis chapter 1 verse 30 to chapter 2 verse 3", "cross-chapter range"},
+		{"Prov 3:5,7,9-11", "Proverbs chapter 3 verses 5, 7 and 9 to 11", "verse list"},
+		{"Matt 5:3a-5b", "Matthew chapter 5 verses 3a to 5b", "verse parts"},
+		{"Prov 3:5ff", "Proverbs chapter 3 verses 5 and following", "following verses"},
+		{"Jude 4", "Jude chapter 1 verse 4", "single-chapter book"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ref := bibleref.MustParse(tc.input, tbl)
+			if got := ref.FormatSpoken(tbl); got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+
+	unknown := bibleref.BibleRef{OSIS: "Foo", Chapter: 2}
+	if got := unknown.FormatSpoken(tbl); got != "Foo chapter 2" {
+		t.Errorf("expected OSIS fallback %q, got %q", "Foo chapter 2", got)
+	}
+}
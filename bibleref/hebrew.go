@@ -0,0 +1,92 @@
+package bibleref
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Unicode bidi isolate controls used to embed a Hebrew reference in running text, and the
+// punctuation and letters of Hebrew numerals.
+const (
+	rtlIsolate     = "\u2067"
+	popIsolate     = "\u2069"
+	geresh         = "\u05f3"
+	gershayim      = "\u05f4"
+	hebrewUnits    = "אבגדהוזחט"
+	hebrewTens     = "יכלמנסעפצ"
+	hebrewHundreds = "קרשת"
+)
+
+// formatHebrew renders the BibleRef for right-to-left interfaces as the book's HebrewName
+// (or Name when it has none) followed by the chapter and verse, wrapped in a right-to-left
+// isolate so that it displays correctly inside left-to-right text. When numerals is true, the
+// chapter and verse numbers are written as Hebrew numerals, e.g. "משלי ל״א:י׳–ל״א".
+func (r BibleRef) formatHebrew(tbl *Table, numerals bool) string {
+	if !numerals {
+		return rtlIsolate + r.FormatWith(RefTemplate{Book: BookNameHebrew}, tbl) + popIsolate
+	}
+	return rtlIsolate + r.bookName(BookNameHebrew, tbl) + " " + hebrewNumeralsIn(r.chapterVerse(":")) + popIsolate
+}
+
+// hebrewNumeralsIn replaces each run of ASCII digits in s with its Hebrew numeral.
+func hebrewNumeralsIn(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		j := i
+		for j < len(s) && s[j] >= '0' && s[j] <= '9' {
+			j++
+		}
+		if j == i {
+			b.WriteByte(s[i])
+			i++
+			continue
+		}
+		n, _ := strconv.Atoi(s[i:j])
+		b.WriteString(hebrewNumeral(n))
+		i = j
+	}
+	return b.String()
+}
+
+// hebrewNumeral returns n written as a Hebrew numeral (gematria), e.g. "א׳" for 1 and "קי״ט"
+// for 119. A single letter is marked with a geresh and longer numerals with a gershayim before
+// the last letter. 15 and 16 are written "ט״ו" and "ט״ז" to avoid spelling the divine name.
+// Hundreds beyond 400 repeat ת. It returns the decimal form for numbers less than 1.
+func hebrewNumeral(n int) string {
+	if n < 1 {
+		return strconv.Itoa(n)
+	}
+
+	units := []rune(hebrewUnits)
+	tens := []rune(hebrewTens)
+	hundreds := []rune(hebrewHundreds)
+
+	var letters []rune
+	for ; n >= 400; n -= 400 {
+		letters = append(letters, hundreds[3])
+	}
+	if n >= 100 {
+		letters = append(letters, hundreds[n/100-1])
+		n %= 100
+	}
+	switch n {
+	case 15:
+		letters = append(letters, units[8], units[5])
+	case 16:
+		letters = append(letters, units[8], units[6])
+	default:
+		if n >= 10 {
+			letters = append(letters, tens[n/10-1])
+			n %= 10
+		}
+		if n > 0 {
+			letters = append(letters, units[n-1])
+		}
+	}
+
+	if len(letters) == 1 {
+		return string(letters) + geresh
+	}
+	return fmt.Sprintf("%s%s%s", string(letters[:len(letters)-1]), gershayim, string(letters[len(letters)-1]))
+}
@@ -0,0 +1,68 @@
+package bibleref
+
+import (
+	"encoding/json"
+
+	"github.com/julianstephens/canonref/util"
+)
+
+// bibleRefJSON is the JSON form of a BibleRef.
+type bibleRefJSON struct {
+	OSIS       string            `json:"osis"`
+	Chapter    int               `json:"chapter"`
+	EndChapter *int              `json:"end_chapter,omitempty"`
+	Verse      *util.VerseRange  `json:"verse,omitempty"`
+	Additional []util.VerseRange `json:"additional,omitempty"`
+}
+
+// MarshalJSON encodes the BibleRef as a compact object, e.g.
+// {"osis":"Prov","chapter":31,"verse":{"start":10,"end":31}}. The verse is omitted for
+// chapter-only references.
+func (r BibleRef) MarshalJSON() ([]byte, error) {
+	return json.Marshal(bibleRefJSON{
+		OSIS:       r.OSIS,
+		Chapter:    r.Chapter,
+		EndChapter: r.EndChapter,
+		Verse:      r.Verse,
+		Additional: r.Additional,
+	})
+}
+
+// UnmarshalJSON decodes a BibleRef encoded by MarshalJSON. It only checks the structure of
+// the data; use UnmarshalAndValidate to also validate the reference against a Table.
+func (r *BibleRef) UnmarshalJSON(data []byte) error {
+	var v bibleRefJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return &BibleRefError{
+			Kind:    KindParse,
+			Err:     ErrBibleRefParseFailed,
+			Message: util.Ptr("failed to parse BibleRef JSON"),
+			Cause:   err,
+		}
+	}
+
+	*r = BibleRef{
+		OSIS:       v.OSIS,
+		Chapter:    v.Chapter,
+		EndChapter: v.EndChapter,
+		Verse:      v.Verse,
+		Additional: v.Additional,
+	}
+	return nil
+}
+
+// UnmarshalAndValidate decodes a BibleRef encoded by MarshalJSON and validates it against
+// the provided Table. It returns a BibleRefError if the data cannot be decoded or the
+// reference is invalid.
+func UnmarshalAndValidate(data []byte, tbl *Table) (*BibleRef, error) {
+	var ref BibleRef
+	if err := json.Unmarshal(data, &ref); err != nil {
+		return nil, err
+	}
+
+	if err := ref.Validate(tbl); err != nil {
+		return nil, err
+	}
+
+	return &ref, nil
+}
@@ -0,0 +1,82 @@
+package bibleref_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/julianstephens/canonref/bibleref"
+)
+
+// TestBibleRef_JSON tests the compact JSON encoding and that it round-trips exactly.
+func TestBibleRef_JSON(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected string
+		desc     string
+	}{
+		{"Prov 31:10-31", `{"osis":"Prov","chapter":31,"verse":{"start":10,"end":31}}`, "verse range"},
+		{"Prov 3:5", `{"osis":"Prov","chapter":3,"verse":{"start":5}}`, "single verse"},
+		{"Prov 31", `{"osis":"Prov","chapter":31}`, "chapter-only omits verse"},
+		{"Gen 1:30-2:3", `{"osis":"Gen","chapter":1,"end_chapter":2,"verse":{"start":30,"end":3}}`, "cross-chapter range"},
+		{"Matt 5:3,5", `{"osis":"Matt","chapter":5,"verse":{"start":3},"additional":[{"start":5}]}`, "verse list"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ref := bibleref.MustParse(tc.input, tbl)
+			data, err := json.Marshal(ref)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			if string(data) != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, data)
+			}
+
+			var decoded bibleref.BibleRef
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			if decoded.String() != ref.String() || decoded.IsChapterOnly() != ref.IsChapterOnly() {
+				t.Errorf("round trip: expected %v, got %v", ref, decoded)
+			}
+		})
+	}
+
+	// UnmarshalJSON is structural only, so an unknown book decodes without a table
+	var unknown bibleref.BibleRef
+	if err := json.Unmarshal([]byte(`{"osis":"Nope","chapter":1}`), &unknown); err != nil {
+		t.Errorf("expected structural decode to succeed, got %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{"osis":"Prov","chapter":"x"}`), &unknown); !errors.Is(err, bibleref.ErrBibleRefParseFailed) {
+		t.Errorf("expected ErrBibleRefParseFailed for malformed JSON, got %v", err)
+	}
+}
+
+// TestUnmarshalAndValidate tests decoding with validation against a Table.
+func TestUnmarshalAndValidate(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	ref, err := bibleref.UnmarshalAndValidate([]byte(`{"osis":"Prov","chapter":31}`), tbl)
+	if err != nil {
+		t.Fatalf("UnmarshalAndValidate failed: %v", err)
+	}
+	if ref.String() != "Prov 31" {
+		t.Errorf("expected %q, got %q", "Prov 31", ref.String())
+	}
+
+	if _, err := bibleref.UnmarshalAndValidate([]byte(`{"osis":"Prov","chapter":32}`), tbl); !errors.Is(err, bibleref.ErrInvalidChapter) {
+		t.Errorf("expected ErrInvalidChapter, got %v", err)
+	}
+	if _, err := bibleref.UnmarshalAndValidate([]byte(`{"osis":"Nope","chapter":1}`), tbl); !errors.Is(err, bibleref.ErrInvalidOSISCode) {
+		t.Errorf("expected ErrInvalidOSISCode, got %v", err)
+	}
+}
@@ -0,0 +1,597 @@
+package bibleref
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/julianstephens/canonref/util"
+)
+
+// FormatList renders a list of references as a compact grouped citation, e.g.
+// "Prov 3:5–6,8; Matt 1:1". References are sorted in canonical order using the Table,
+// overlapping and adjacent verses within a chapter are merged into ranges, verses in the same
+// chapter are joined by commas, and chapters are separated by semicolons. A chapter that
+// follows another chapter of the same book omits the book code ("Prov 3:5; 4:1"), and a
+// chapter-only reference absorbs any verses of that chapter. Cross-chapter ranges are kept
+// and chapter ranges are kept as their own entries ("Gen 1:30–2:3", "Gen 1–3").
+func FormatList(refs []BibleRef, tbl *Table) string {
+	var b strings.Builder
+	prevOSIS := ""
+	for i, g := range groupByChapter(refs, tbl) {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		if g.osis != prevOSIS {
+			b.WriteString(g.osis + " ")
+		}
+		b.WriteString(strconv.Itoa(g.chapter))
+		switch {
+		case g.endChapter != 0 && g.whole:
+			fmt.Fprintf(&b, "%s%d", util.EnDash, g.endChapter)
+		case g.endChapter != 0:
+			fmt.Fprintf(&b, ":%d%s%d:%d", g.spans[0].start, util.EnDash, g.endChapter, g.spans[0].end)
+		case !g.whole:
+			b.WriteString(":")
+			for j, sp := range g.spans {
+				if j > 0 {
+					b.WriteString(",")
+				}
+				b.WriteString(sp.String())
+			}
+		}
+		prevOSIS = g.osis
+	}
+	return b.String()
+}
+
+// ParseList parses a list of references separated by semicolons, commas, or the word "and",
+// e.g. "Prov 3:5, 8; 4:1 and Matt 1:1". A segment without a book continues the previous
+// reference: after a comma or "and" a bare number is another verse of the same chapter when
+// the previous reference cites verses, and otherwise it is a chapter of the same book, as is
+// a bare number or chapter:verse after a semicolon. Empty segments, such as those left by
+// trailing or doubled separators ("Prov 3:5; Matt 1:1;"), are skipped. The word "and" only
+// separates references after a number, so it may appear in a book name, as in
+// "Bel and the Dragon 3".
+// The colon is what tells the two apart: "Prov 3,5,7" is three chapters, Prov 3, Prov 5, and
+// Prov 7, while "Prov 3:5,7" is two verses of Prov 3. Note that Parse, which reads a single
+// reference, takes the comma in "Prov 3,5" as a chapter-verse separator instead.
+// A verse continuation that overlaps or repeats the previous segment is merged into it, so
+// "Prov 3:5,5-8" yields Prov 3:5–8; with WithStrictVerseLists it is reported as an error instead.
+// It returns the first error encountered.
+func ParseList(s string, tbl *Table, opts ...ParseOption) ([]BibleRef, error) {
+	cfg := newParseConfig(opts)
+	var refs []BibleRef
+	var prev *BibleRef
+	for _, group := range strings.Split(s, ";") {
+		for i, seg := range splitListSegments(group, tbl) {
+			if seg == "" {
+				continue
+			}
+			expanded, sameChapter := expandListSegment(seg, prev, i > 0)
+			ref, err := Parse(expanded, tbl, opts...)
+			if err != nil {
+				return nil, &BibleRefError{
+					Kind:    KindParse,
+					Err:     ErrBibleRefParseFailed,
+					Message: util.Ptr(fmt.Sprintf("failed to parse list segment: %s", seg)),
+					Cause:   err,
+				}
+			}
+			if sameChapter && overlaps(*prev, *ref) {
+				if cfg.strictVerseLists {
+					return nil, &BibleRefError{
+						Kind:    KindInvalidVerse,
+						Err:     ErrInvalidVerse,
+						Message: util.Ptr(fmt.Sprintf("verse segment %s overlaps %s", seg, prev)),
+					}
+				}
+				start, end := prev.span()
+				segStart, segEnd := ref.span()
+				*prev = verseSpanRef(prev.OSIS, prev.Chapter, min(start, segStart), max(end, segEnd))
+				continue
+			}
+			refs = append(refs, *ref)
+			prev = &refs[len(refs)-1]
+		}
+	}
+	return refs, nil
+}
+
+// overlaps reports whether two single-chapter verse references share at least one verse.
+func overlaps(a, b BibleRef) bool {
+	if a.Verse == nil || b.Verse == nil || a.EndChapter != nil || b.EndChapter != nil {
+		return false
+	}
+	aStart, aEnd := a.span()
+	bStart, bEnd := b.span()
+	return aStart <= bEnd && bStart <= aEnd
+}
+
+// splitListSegments splits one semicolon-delimited group of a list on commas and the word
+// "and", returning the trimmed segments in order. Segments may be empty.
+func splitListSegments(group string, tbl *Table) []string {
+	var segs []string
+	for _, part := range strings.Split(group, ",") {
+		var cur []string
+		fields := strings.Fields(part)
+		for i, f := range fields {
+			if strings.EqualFold(f, "and") && separatesList(cur, fields[i+1:], tbl) {
+				segs = append(segs, strings.Join(cur, " "))
+				cur = nil
+				continue
+			}
+			cur = append(cur, f)
+		}
+		segs = append(segs, strings.Join(cur, " "))
+	}
+	return segs
+}
+
+// separatesList reports whether the word "and" between the words before and after it
+// separates two list segments rather than being part of a book name, as in "Bel and the
+// Dragon". It does when nothing precedes it, or when the last word before it holds a number,
+// as the end of a reference does, and the words after it are missing or start with a number
+// or a book name.
+func separatesList(before, after []string, tbl *Table) bool {
+	if len(before) == 0 {
+		return true
+	}
+	if strings.IndexFunc(before[len(before)-1], unicode.IsDigit) < 0 {
+		return false
+	}
+	return len(after) == 0 || startsWithDigit(after[0]) || startsBookName(tbl, after[0])
+}
+
+// startsBookName reports whether word is the first word of an alias or OSIS code in the Table.
+func startsBookName(tbl *Table, word string) bool {
+	if tbl == nil {
+		return false
+	}
+	word = NormalizeAlias(word)
+	if word == "" {
+		return false
+	}
+	for alias := range tbl.ByAlias {
+		if alias == word || strings.HasPrefix(alias, word+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// expandListSegment returns seg as a complete reference string, filling in the book and,
+// for a verse continuation, the chapter from prev. continued reports that seg followed a
+// comma or "and" rather than a semicolon. Segments that name a book are returned unchanged.
+// The boolean result reports a verse continuation of the chapter prev ends in.
+func expandListSegment(seg string, prev *BibleRef, continued bool) (string, bool) {
+	if prev == nil || strings.IndexFunc(seg, unicode.IsLetter) >= 0 {
+		return seg, false
+	}
+	if continued && prev.Verse != nil && !strings.Contains(seg, ":") {
+		return fmt.Sprintf("%s %d:%s", prev.OSIS, prev.endChapter(), seg), true
+	}
+	return fmt.Sprintf("%s %s", prev.OSIS, seg), false
+}
+
+// ParseLines parses a block of text containing one reference per line.
+// Lines are trimmed and blank lines are skipped. The returned slices are aligned with the
+// non-blank lines: for each line, either refs[i] is set and errs[i] is nil, or refs[i] is nil
+// and errs[i] holds the parse error.
+func ParseLines(text string, tbl *Table, opts ...ParseOption) ([]*BibleRef, []error) {
+	var refs []*BibleRef
+	var errs []error
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		ref, err := Parse(line, tbl, opts...)
+		refs = append(refs, ref)
+		errs = append(errs, err)
+	}
+	return refs, errs
+}
+
+// verseSpan is an inclusive range of verses within a single chapter.
+type verseSpan struct {
+	start, end int
+}
+
+func (s verseSpan) String() string {
+	if s.start == s.end {
+		return strconv.Itoa(s.start)
+	}
+	if s.end == unknownChapterSize {
+		return strconv.Itoa(s.start) + util.FollowingVerses
+	}
+	return fmt.Sprintf("%d%s%d", s.start, util.EnDash, s.end)
+}
+
+// chapterGroup collects the verses cited in one chapter of a book.
+// whole is set when the chapter is cited without verses, in which case spans is empty.
+// endChapter is set for a cross-chapter range, whose single span ends in endChapter.
+type chapterGroup struct {
+	osis       string
+	chapter    int
+	endChapter int
+	whole      bool
+	spans      []verseSpan
+}
+
+// groupByChapter sorts refs in canonical order and groups them by book and chapter,
+// merging the verse spans of each chapter.
+func groupByChapter(refs []BibleRef, tbl *Table) []chapterGroup {
+	sorted := slices.Clone(refs)
+	SortRefs(sorted, tbl)
+
+	var groups []chapterGroup
+	for _, ref := range sorted {
+		if ref.EndChapter != nil && ref.Verse != nil && ref.Verse.EndVerse != nil {
+			groups = append(groups, chapterGroup{
+				osis:       ref.OSIS,
+				chapter:    ref.Chapter,
+				endChapter: *ref.EndChapter,
+				spans:      []verseSpan{{start: ref.Verse.StartVerse, end: *ref.Verse.EndVerse}},
+			})
+			continue
+		}
+		if ref.EndChapter != nil && ref.Verse == nil {
+			groups = append(groups, chapterGroup{osis: ref.OSIS, chapter: ref.Chapter, endChapter: *ref.EndChapter, whole: true})
+			continue
+		}
+		if n := len(groups); n == 0 || groups[n-1].osis != ref.OSIS || groups[n-1].chapter != ref.Chapter || groups[n-1].endChapter != 0 {
+			groups = append(groups, chapterGroup{osis: ref.OSIS, chapter: ref.Chapter})
+		}
+		g := &groups[len(groups)-1]
+		if ref.Verse == nil {
+			g.whole = true
+			continue
+		}
+		start, end := ref.span()
+		g.spans = append(g.spans, verseSpan{start: start, end: end})
+		for _, v := range ref.Additional {
+			start, end := segmentSpan(v)
+			g.spans = append(g.spans, verseSpan{start: start, end: end})
+		}
+	}
+
+	for i := range groups {
+		if groups[i].endChapter != 0 {
+			continue
+		}
+		if groups[i].whole {
+			groups[i].spans = nil
+			continue
+		}
+		slices.SortFunc(groups[i].spans, func(a, b verseSpan) int {
+			return cmp.Or(a.start-b.start, a.end-b.end)
+		})
+		groups[i].spans = mergeSpans(groups[i].spans)
+	}
+	return groups
+}
+
+// mergeSpans merges overlapping and adjacent spans. The input must be sorted by start verse.
+func mergeSpans(spans []verseSpan) []verseSpan {
+	var res []verseSpan
+	for _, sp := range spans {
+		if n := len(res); n > 0 && sp.start <= res[n-1].end+1 {
+			res[n-1].end = max(res[n-1].end, sp.end)
+			continue
+		}
+		res = append(res, sp)
+	}
+	return res
+}
+
+// Compare orders r and other canonically, returning -1 if r comes first, +1 if other comes
+// first, and 0 if they are equal in order. References are ordered by the Order of their books
+// in the Table, then by OSIS code for books with equal or unknown order, then by chapter, start
+// verse, end chapter, and end verse. Chapter-only references sort before verse references in
+// the same chapter, and a shorter chapter range before a longer one, so Gen 1 comes before
+// Gen 1–3. References that still tie are ordered by the further verses of their verse lists.
+//
+// The Table is consulted only for book Order, so r and other may come from different Table
+// instances for the same canon, and either may be passed, provided the tables agree on the
+// OSIS code and Order of each book. With a nil Table references are ordered by OSIS code.
+func (r BibleRef) Compare(other BibleRef, tbl *Table) int {
+	book, _ := tbl.Book(r.OSIS)
+	otherBook, _ := tbl.Book(other.OSIS)
+	if c := cmp.Compare(book.Order, otherBook.Order); c != 0 {
+		return c
+	}
+	if c := strings.Compare(r.OSIS, other.OSIS); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(r.Chapter, other.Chapter); c != 0 {
+		return c
+	}
+	switch {
+	case r.Verse == nil && other.Verse == nil:
+		return cmp.Compare(r.endChapter(), other.endChapter())
+	case r.Verse == nil:
+		return -1
+	case other.Verse == nil:
+		return 1
+	}
+	aStart, aEnd := r.span()
+	bStart, bEnd := other.span()
+	return cmp.Or(
+		cmp.Compare(aStart, bStart),
+		cmp.Compare(r.endChapter(), other.endChapter()),
+		cmp.Compare(aEnd, bEnd),
+		slices.CompareFunc(r.Additional, other.Additional, func(a, b util.VerseRange) int {
+			aStart, aEnd := segmentSpan(a)
+			bStart, bEnd := segmentSpan(b)
+			return cmp.Or(cmp.Compare(aStart, bStart), cmp.Compare(aEnd, bEnd))
+		}),
+	)
+}
+
+// Equal reports whether r and other are the same reference: the same OSIS code, chapters,
+// verse range, and additional verse ranges. It compares values rather than pointers and needs
+// no Table, so references parsed with different tables are equal when their OSIS codes match.
+// How the book was typed, as recorded by WithRetainedAlias, and Meta are not compared.
+func (r BibleRef) Equal(other BibleRef) bool {
+	if r.OSIS != other.OSIS || r.Chapter != other.Chapter || r.endChapter() != other.endChapter() {
+		return false
+	}
+	if (r.Verse == nil) != (other.Verse == nil) || (r.Verse != nil && !r.Verse.Equal(*other.Verse)) {
+		return false
+	}
+	return slices.EqualFunc(r.Additional, other.Additional, util.VerseRange.Equal)
+}
+
+// SortRefs sorts refs in place in canonical order as defined by Compare. The sort is stable,
+// so references that compare equal keep their relative order.
+func SortRefs(refs []BibleRef, tbl *Table) {
+	slices.SortStableFunc(refs, func(a, b BibleRef) int {
+		return a.Compare(b, tbl)
+	})
+}
+
+// CheckSequential reports, as an advisory lint, each place where a reference comes before the
+// one preceding it in canonical order as defined by Compare, as in "Prov 3; Prov 1". Each
+// warning names both references and its position in refs. It returns nil when refs are in
+// order; repeated and overlapping references are not reported.
+func CheckSequential(refs []BibleRef, tbl *Table) []string {
+	var warnings []string
+	for i := 1; i < len(refs); i++ {
+		if refs[i].Compare(refs[i-1], tbl) < 0 {
+			warnings = append(warnings, fmt.Sprintf("reference %d (%s) comes before the preceding reference (%s)", i+1, refs[i], refs[i-1]))
+		}
+	}
+	return warnings
+}
+
+// DifferenceLists returns the verses cited in a that are not cited in b, such as the verses
+// of a reading plan not yet read. Both lists are expanded to the verses of each chapter they
+// cite, the verses of b are removed, and the remaining verses are merged back into ranges as
+// FormatList does, giving one reference per remaining span in canonical order. A chapter left
+// whole is returned as a chapter-only reference.
+//
+// Whole chapters and cross-chapter ranges are expanded using the book's VerseCounts, from
+// verse 0 for a book with AllowVerseZero, and an "ff" range runs to the end of its chapter. A
+// chapter of a without a verse count can only be removed whole: when a cites all of it and b
+// just some of its verses, the chapter is kept as a chapter-only reference. What remains of
+// an "ff" range in such a chapter keeps the "ff" notation.
+func DifferenceLists(a, b []BibleRef, tbl *Table) []BibleRef {
+	remove := make(map[chapterKey][]verseSpan)
+	for _, cs := range expandChapters(b, tbl) {
+		remove[cs.chapterKey] = cs.spans
+	}
+
+	var res []BibleRef
+	for _, cs := range expandChapters(a, tbl) {
+		size := chapterSize(tbl, cs.osis, cs.chapter)
+		rest := subtractSpans(cs.spans, remove[cs.chapterKey])
+		if len(rest) == 0 {
+			continue
+		}
+		whole := verseSpan{start: chapterStart(tbl, cs.osis), end: size}
+		if len(rest) == 1 && rest[0] == whole || size == unknownChapterSize && cs.spans[0] == whole {
+			res = append(res, BibleRef{OSIS: cs.osis, Chapter: cs.chapter})
+			continue
+		}
+		for _, sp := range rest {
+			res = append(res, verseSpanRef(cs.osis, cs.chapter, sp.start, sp.end))
+		}
+	}
+	return res
+}
+
+// chapterKey identifies a chapter of a book.
+type chapterKey struct {
+	osis    string
+	chapter int
+}
+
+// chapterSpans holds the merged verse spans cited in one chapter.
+type chapterSpans struct {
+	chapterKey
+	spans []verseSpan
+}
+
+// unknownChapterSize stands in for the last verse of a chapter without a verse count.
+const unknownChapterSize = verseLimit - 1
+
+// chapterSize returns the number of verses in the chapter, or unknownChapterSize when the book
+// is not in the Table or has no verse count for it.
+func chapterSize(tbl *Table, osis string, chapter int) int {
+	book, _ := tbl.Book(osis)
+	if n, ok := book.VersesIn(chapter); ok {
+		return n
+	}
+	return unknownChapterSize
+}
+
+// chapterStart returns the first verse of a chapter of the book: 0 for a book with
+// AllowVerseZero, otherwise 1.
+func chapterStart(tbl *Table, osis string) int {
+	book, _ := tbl.Book(osis)
+	return book.firstVerse()
+}
+
+// expandChapters sorts refs in canonical order and expands them to the verses they cite in
+// each chapter, with the spans of each chapter sorted and merged. Whole chapters span from
+// their chapterStart to their chapterSize.
+func expandChapters(refs []BibleRef, tbl *Table) []chapterSpans {
+	sorted := slices.Clone(refs)
+	SortRefs(sorted, tbl)
+
+	var res []chapterSpans
+	index := make(map[chapterKey]int)
+	add := func(osis string, chapter, start, end int) {
+		if end == unknownChapterSize {
+			end = chapterSize(tbl, osis, chapter)
+		}
+		key := chapterKey{osis: osis, chapter: chapter}
+		i, ok := index[key]
+		if !ok {
+			i = len(res)
+			index[key] = i
+			res = append(res, chapterSpans{chapterKey: key})
+		}
+		res[i].spans = append(res[i].spans, verseSpan{start: start, end: end})
+	}
+
+	for _, ref := range sorted {
+		endChapter := ref.endChapter()
+		if ref.Verse == nil || ref.EndChapter != nil {
+			for ch := ref.Chapter; ch <= endChapter; ch++ {
+				start, end := chapterStart(tbl, ref.OSIS), chapterSize(tbl, ref.OSIS, ch)
+				if ref.Verse != nil && ch == ref.Chapter {
+					start = ref.Verse.StartVerse
+				}
+				if ref.Verse != nil && ref.Verse.EndVerse != nil && ch == endChapter {
+					end = *ref.Verse.EndVerse
+				}
+				add(ref.OSIS, ch, start, end)
+			}
+			continue
+		}
+		start, end := ref.span()
+		add(ref.OSIS, ref.Chapter, start, end)
+		for _, v := range ref.Additional {
+			start, end := segmentSpan(v)
+			add(ref.OSIS, ref.Chapter, start, end)
+		}
+	}
+
+	for i := range res {
+		slices.SortFunc(res[i].spans, func(a, b verseSpan) int {
+			return cmp.Or(a.start-b.start, a.end-b.end)
+		})
+		res[i].spans = mergeSpans(res[i].spans)
+	}
+	return res
+}
+
+// subtractSpans removes the verses of remove from spans. Both must be sorted and merged.
+func subtractSpans(spans, remove []verseSpan) []verseSpan {
+	var res []verseSpan
+	for _, sp := range spans {
+		for _, r := range remove {
+			if r.end < sp.start || r.start > sp.end {
+				continue
+			}
+			if r.start > sp.start {
+				res = append(res, verseSpan{start: sp.start, end: r.start - 1})
+			}
+			sp.start = r.end + 1
+			if sp.start > sp.end {
+				break
+			}
+		}
+		if sp.start <= sp.end {
+			res = append(res, sp)
+		}
+	}
+	return res
+}
+
+// Merge combines references that cite overlapping or adjacent verses of the same chapter into
+// minimal spans, so "Prov 31:10–20" and "Prov 31:18–31" become "Prov 31:10–31", and
+// "Prov 3:10–12" and "Prov 3:13–15" become "Prov 3:10–15". Verses that are not adjacent stay
+// separate references, one per span, and a chapter-only reference absorbs every verse of its
+// chapter. Chapters are returned in the order they first appear, with their spans in verse
+// order; use SortRefs for canonical order. Verses with a part or the "f" or "ff" notation are
+// not combined with others, and cross-chapter and chapter ranges are kept as given.
+func Merge(refs []BibleRef) []BibleRef {
+	var groups []mergeGroup
+	index := make(map[chapterKey]int)
+	for _, ref := range refs {
+		if ref.EndChapter != nil {
+			groups = append(groups, mergeGroup{passThrough: true, kept: []BibleRef{ref}})
+			continue
+		}
+		key := chapterKey{osis: ref.OSIS, chapter: ref.Chapter}
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, mergeGroup{chapterGroup: chapterGroup{osis: ref.OSIS, chapter: ref.Chapter}})
+		}
+		g := &groups[i]
+		switch {
+		case ref.Verse == nil:
+			g.whole = true
+		case hasVerseNotation(ref):
+			g.kept = append(g.kept, ref)
+		default:
+			for _, v := range append([]util.VerseRange{*ref.Verse}, ref.Additional...) {
+				start, end := segmentSpan(v)
+				g.spans = append(g.spans, verseSpan{start: start, end: end})
+			}
+		}
+	}
+
+	var res []BibleRef
+	for _, g := range groups {
+		if g.passThrough {
+			res = append(res, g.kept...)
+			continue
+		}
+		if g.whole {
+			res = append(res, BibleRef{OSIS: g.osis, Chapter: g.chapter})
+			continue
+		}
+		slices.SortFunc(g.spans, func(a, b verseSpan) int {
+			return cmp.Or(a.start-b.start, a.end-b.end)
+		})
+		chapter := slices.Clone(g.kept)
+		for _, sp := range mergeSpans(g.spans) {
+			chapter = append(chapter, verseSpanRef(g.osis, g.chapter, sp.start, sp.end))
+		}
+		slices.SortStableFunc(chapter, func(a, b BibleRef) int {
+			aStart, aEnd := a.span()
+			bStart, bEnd := b.span()
+			return cmp.Or(aStart-bStart, aEnd-bEnd)
+		})
+		res = append(res, chapter...)
+	}
+	return res
+}
+
+// mergeGroup collects what Merge combines for one chapter. kept holds references passed
+// through unmerged; a passThrough group holds just a cross-chapter or chapter range.
+type mergeGroup struct {
+	chapterGroup
+	passThrough bool
+	kept        []BibleRef
+}
+
+// hasVerseNotation reports whether any verse segment of ref has a verse part or the "f" or
+// "ff" notation, which a plain verse span cannot represent.
+func hasVerseNotation(ref BibleRef) bool {
+	for _, v := range append([]util.VerseRange{*ref.Verse}, ref.Additional...) {
+		if v.StartPart != "" || v.EndPart != "" || v.Following != "" {
+			return true
+		}
+	}
+	return false
+}
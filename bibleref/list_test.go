@@ -0,0 +1,478 @@
+package bibleref_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/julianstephens/canonref/bibleref"
+	"github.com/julianstephens/canonref/util"
+)
+
+// TestFormatList tests grouping, range merging, and multi-book separation of reference lists.
+func TestFormatList(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		inputs   []string
+		expected string
+		desc     string
+	}{
+		{[]string{"Prov 3:5", "Prov 3:6", "Prov 3:8", "Matt 1:1"}, "Prov 3:5–6,8; Matt 1:1", "adjacent verses merged"},
+		{[]string{"Matt 1:1", "Prov 3:8", "Prov 3:5", "Prov 3:6"}, "Prov 3:5–6,8; Matt 1:1", "unsorted input"},
+		{[]string{"Prov 3:5-8", "Prov 3:7-10", "Prov 3:12"}, "Prov 3:5–10,12", "overlapping ranges merged"},
+		{[]string{"Prov 4:1", "Prov 3:5", "Gen 1:1"}, "Gen 1:1; Prov 3:5; 4:1", "repeated book omitted"},
+		{[]string{"Prov 3:5", "Prov 3"}, "Prov 3", "chapter absorbs verses"},
+		{[]string{"Prov 3:5", "Prov 3:5"}, "Prov 3:5", "duplicates collapsed"},
+		{[]string{"Gen 2:1", "Gen 1:30-2:3"}, "Gen 1:30–2:3; 2:1", "cross-chapter range kept separate"},
+		{[]string{"Prov 3:8,1-2", "Prov 3:5"}, "Prov 3:1–2,5,8", "verse list segments merged"},
+		{[]string{"Gen 4:1", "Gen 1-3"}, "Gen 1–3; 4:1", "chapter range kept separate"},
+		{[]string{"Prov 3:12ff", "Prov 3:5-14"}, "Prov 3:5ff", "ff absorbs a range it overlaps"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			refs := make([]bibleref.BibleRef, len(tc.inputs))
+			for i, input := range tc.inputs {
+				refs[i] = *bibleref.MustParse(input, tbl)
+			}
+			if got := bibleref.FormatList(refs, tbl); got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+// TestParseLines tests parsing one reference per line with blank and invalid lines.
+func TestParseLines(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	text := "Prov 3:5\r\n\n   \nMatt 1:1-3\nNotABook 1:1\n  Gen 1  \n"
+	refs, errs := bibleref.ParseLines(text, tbl)
+	if len(refs) != 4 || len(errs) != 4 {
+		t.Fatalf("expected 4 aligned results, got %d refs and %d errors", len(refs), len(errs))
+	}
+
+	expected := []string{"Prov 3:5", "Matt 1:1–3", "", "Gen 1"}
+	for i, want := range expected {
+		if want == "" {
+			if errs[i] == nil || refs[i] != nil {
+				t.Errorf("line %d: expected error and nil ref, got ref %v err %v", i, refs[i], errs[i])
+			}
+			continue
+		}
+		if errs[i] != nil {
+			t.Errorf("line %d: unexpected error: %v", i, errs[i])
+			continue
+		}
+		if refs[i].String() != want {
+			t.Errorf("line %d: expected %q, got %q", i, want, refs[i].String())
+		}
+	}
+}
+
+// TestParseList tests parsing separated lists, carrying the book and chapter forward and
+// skipping empty segments left by trailing or dangling separators.
+func TestParseList(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected string
+		desc     string
+	}{
+		{"Prov 3:5; Matt 1:1", "Prov 3:5; Matt 1:1", "semicolon separated"},
+		{"Prov 3:5, 8; 4:1 and Matt 1:1", "Prov 3:5; Prov 3:8; Prov 4:1; Matt 1:1", "book and chapter carried forward"},
+		{"Prov 3, 5", "Prov 3; Prov 5", "chapter continuation"},
+		{"Prov 3,5,7", "Prov 3; Prov 5; Prov 7", "comma-separated chapters"},
+		{"Prov 3, 5, 7", "Prov 3; Prov 5; Prov 7", "spaced comma-separated chapters"},
+		{"Prov 3:5,7", "Prov 3:5; Prov 3:7", "comma-separated verses after a colon"},
+		{"Prov 3,5:1", "Prov 3; Prov 5:1", "chapter then chapter:verse"},
+		{"Prov 3:5; 1 Sam 3:1", "Prov 3:5; 1Sam 3:1", "digit-prefixed book"},
+		{"Prov 3:5; Matt 1:1;", "Prov 3:5; Matt 1:1", "trailing semicolon"},
+		{"Prov 3:5, Matt 1:1,", "Prov 3:5; Matt 1:1", "trailing comma"},
+		{"Prov 3:5 and Matt 1:1 and", "Prov 3:5; Matt 1:1", "trailing and"},
+		{"; Prov 3:5;; , and Matt 1:1 ;,", "Prov 3:5; Matt 1:1", "dangling separators"},
+		{"Prov 3:5 and 1 Sam 3:1", "Prov 3:5; 1Sam 3:1", "and before a numbered book"},
+		{"Prov 3:5a and matthew 1:1", "Prov 3:5a; Matt 1:1", "and after a verse part"},
+		{" ; , ", "", "only separators"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			refs, err := bibleref.ParseList(tc.input, tbl)
+			if err != nil {
+				t.Fatalf("ParseList(%q) failed: %v", tc.input, err)
+			}
+			if got := joinRefs(refs); got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+
+	if _, err := bibleref.ParseList("Prov 3:5; NotABook 1:1", tbl); err == nil {
+		t.Error("expected an invalid segment to be reported")
+	}
+}
+
+// TestParseList_OverlappingSegments tests that overlapping verse segments of one reference are
+// merged by default and rejected in strict mode.
+func TestParseList_OverlappingSegments(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected string
+		strictOk bool
+		desc     string
+	}{
+		{"Prov 3:5,5-8", "Prov 3:5–8", false, "implicit same start"},
+		{"Prov 3:5,5", "Prov 3:5", false, "duplicate verse"},
+		{"Prov 3:5-8, 7-10, 12", "Prov 3:5–10; Prov 3:12", false, "overlapping ranges"},
+		{"Prov 3:5, 6", "Prov 3:5; Prov 3:6", true, "adjacent verses kept"},
+		{"Prov 3:5; Prov 3:5", "Prov 3:5; Prov 3:5", true, "separate references not merged"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			refs, err := bibleref.ParseList(tc.input, tbl)
+			if err != nil {
+				t.Fatalf("ParseList(%q) failed: %v", tc.input, err)
+			}
+			if got := joinRefs(refs); got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+
+			_, err = bibleref.ParseList(tc.input, tbl, bibleref.WithStrictVerseLists())
+			if tc.strictOk && err != nil {
+				t.Errorf("strict ParseList(%q) failed: %v", tc.input, err)
+			}
+			if !tc.strictOk && !errors.Is(err, bibleref.ErrInvalidVerse) {
+				t.Errorf("strict ParseList(%q): expected ErrInvalidVerse, got %v", tc.input, err)
+			}
+		})
+	}
+}
+
+// TestBibleRef_Compare tests canonical ordering across testaments and between chapter-only and
+// verse references.
+func TestBibleRef_Compare(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		a, b     string
+		expected int
+		desc     string
+	}{
+		{"Gen 50:1", "Matt 1:1", -1, "OT before NT"},
+		{"Matt 1:1", "Wis 1:1", -1, "NT before Apocrypha by order"},
+		{"Ps 23", "Prov 1", -1, "book order before chapter"},
+		{"Prov 3", "Prov 3:1", -1, "chapter-only before verses"},
+		{"Prov 3:5", "Prov 3", 1, "verses after chapter-only"},
+		{"Prov 3:5", "Prov 3:5-8", -1, "single verse before longer range"},
+		{"Prov 3:5-8", "Prov 3:5-8", 0, "equal"},
+		{"Prov 4:1", "Prov 3:20", 1, "later chapter"},
+		{"Gen 1", "Gen 1-3", -1, "chapter before chapter range"},
+		{"Gen 1-3", "Gen 1-2", 1, "longer chapter range after shorter"},
+		{"Gen 1:30-31", "Gen 1:30-2:3", -1, "same-chapter range before cross-chapter range"},
+		{"Gen 1:30-2:3", "Gen 1:30-3:1", -1, "earlier end chapter first"},
+		{"Prov 3:5", "Prov 3:5,7", -1, "verse before verse list"},
+		{"Prov 3:5,7", "Prov 3:5,8", -1, "verse lists by further verses"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			a, b := bibleref.MustParse(tc.a, tbl), bibleref.MustParse(tc.b, tbl)
+			if got := a.Compare(*b, tbl); got != tc.expected {
+				t.Errorf("Compare(%q, %q) = %d, expected %d", tc.a, tc.b, got, tc.expected)
+			}
+			if got := b.Compare(*a, tbl); got != -tc.expected {
+				t.Errorf("Compare(%q, %q) = %d, expected %d", tc.b, tc.a, got, -tc.expected)
+			}
+		})
+	}
+
+	gen, matt := bibleref.BibleRef{OSIS: "Gen", Chapter: 1}, bibleref.BibleRef{OSIS: "Matt", Chapter: 1}
+	if got := gen.Compare(matt, nil); got != -1 {
+		t.Errorf("expected a nil Table to order by OSIS code, got %d", got)
+	}
+}
+
+// TestBibleRef_CompareAcrossTables tests comparing and equating references parsed from two
+// equivalent Table instances, passing either table.
+func TestBibleRef_CompareAcrossTables(t *testing.T) {
+	first, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+	books := testBooks()
+	slices.Reverse(books)
+	second, err := bibleref.NewTable(books)
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		a, b     string
+		expected int
+		desc     string
+	}{
+		{"Prov 3:5-6", "proverbs 3:5-6", 0, "same reference"},
+		{"Prov 3:5,7", "Prov 3:5, 7", 0, "same verse list"},
+		{"Gen 1:1", "Matt 1:1", -1, "earlier book"},
+		{"Matt 5", "Gen 50:26", 1, "later book"},
+		{"Prov 3", "Prov 3:1", -1, "chapter-only first"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			a, b := bibleref.MustParse(tc.a, first), bibleref.MustParse(tc.b, second)
+			for _, tbl := range []*bibleref.Table{first, second} {
+				if got := a.Compare(*b, tbl); got != tc.expected {
+					t.Errorf("Compare(%q, %q) = %d, expected %d", tc.a, tc.b, got, tc.expected)
+				}
+			}
+			if got := a.Equal(*b); got != (tc.expected == 0) {
+				t.Errorf("Equal(%q, %q) = %v", tc.a, tc.b, got)
+			}
+		})
+	}
+}
+
+// TestBibleRef_Equal tests structural equality of references.
+func TestBibleRef_Equal(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		a, b     string
+		expected bool
+		desc     string
+	}{
+		{"Prov 3:5", "Prov 3:5", true, "same verse"},
+		{"Prov 3:5", "Prov 3:5-6", false, "verse and range"},
+		{"Prov 3", "Prov 3:1", false, "chapter-only and verse"},
+		{"Gen 1:31-2:3", "Gen 1:31-2:3", true, "same cross-chapter range"},
+		{"Gen 1:31-2:3", "Gen 1:31-3:3", false, "different end chapter"},
+		{"Prov 3:5,7", "Prov 3:5,8", false, "different additional verses"},
+		{"Prov 3:5,7", "Prov 3:5", false, "verse list and verse"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			a, b := bibleref.MustParse(tc.a, tbl), bibleref.MustParse(tc.b, tbl)
+			if got := a.Equal(*b); got != tc.expected {
+				t.Errorf("Equal(%q, %q) = %v, expected %v", tc.a, tc.b, got, tc.expected)
+			}
+		})
+	}
+}
+
+// TestCheckSequential tests warnings for references that regress in canonical order.
+func TestCheckSequential(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected []string
+		desc     string
+	}{
+		{"Prov 3; Prov 1", []string{"reference 2 (Prov 1) comes before the preceding reference (Prov 3)"}, "chapters go backwards"},
+		{"Matt 5:3; Gen 1:1; Prov 3:5; Prov 3:1", []string{
+			"reference 2 (Gen 1:1) comes before the preceding reference (Matt 5:3)",
+			"reference 4 (Prov 3:1) comes before the preceding reference (Prov 3:5)",
+		}, "books and verses go backwards"},
+		{"Gen 1:1; Prov 3; Prov 3:5; Prov 4; Matt 1:1", nil, "ordered"},
+		{"Prov 3:5; Prov 3:5", nil, "repeated"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			refs, err := bibleref.ParseList(tc.input, tbl)
+			if err != nil {
+				t.Fatalf("ParseList(%q) failed: %v", tc.input, err)
+			}
+			if got := bibleref.CheckSequential(refs, tbl); !slices.Equal(got, tc.expected) {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+// TestSortRefs tests sorting references in place in canonical order.
+func TestSortRefs(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	inputs := []string{"Wis 1:1", "Matt 5:3", "Prov 3:5", "Gen 1:1", "Prov 3", "1Sam 3:1", "Prov 3:1-4"}
+	refs := make([]bibleref.BibleRef, len(inputs))
+	for i, input := range inputs {
+		refs[i] = *bibleref.MustParse(input, tbl)
+	}
+
+	bibleref.SortRefs(refs, tbl)
+	expected := "Gen 1:1; 1Sam 3:1; Prov 3; Prov 3:1–4; Prov 3:5; Matt 5:3; Wis 1:1"
+	if got := joinRefs(refs); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+// TestDifferenceLists tests removing the verses of one list from another for overlapping,
+// disjoint, and whole-chapter lists, with and without verse counts.
+func TestDifferenceLists(t *testing.T) {
+	books := append(testBooks(), bibleref.Book{
+		OSIS: "Ruth", Name: "Ruth", Aliases: []string{"ruth"}, Testament: bibleref.TestamentOld, Order: 8, Chapters: 4,
+		VerseCounts: []int{22, 23, 18, 22},
+	})
+	for i := range books {
+		if books[i].OSIS == "Ps" {
+			books[i].AllowVerseZero = true
+			books[i].VerseCounts = slices.Repeat([]int{19}, books[i].Chapters)
+		}
+	}
+	tbl, err := bibleref.NewTable(books)
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		a, b     string
+		expected string
+		desc     string
+	}{
+		{"Prov 3:1-10; Prov 4:1-5", "Prov 3:4-5; Prov 4:1-5", "Prov 3:1–3; Prov 3:6–10", "overlapping"},
+		{"Matt 5:3-5", "Prov 3:5", "Matt 5:3–5", "disjoint"},
+		{"Prov 3:5-8; 3:7-10", "Prov 3:9", "Prov 3:5–8; Prov 3:10", "merged before subtracting"},
+		{"Prov 3:5-8, 10", "Prov 3:6-7", "Prov 3:5; Prov 3:8; Prov 3:10", "verse list"},
+		{"Prov 3:5-8", "Prov 3", "", "whole chapter removed"},
+		{"Prov 3", "Prov 3:5", "Prov 3", "chapter without verse count kept whole"},
+		{"Ruth 1-2", "Ruth 1:5-22", "Ruth 1:1–4; Ruth 2", "chapter range with verse counts"},
+		{"Ruth 1:20-2:3", "Ruth 1:22", "Ruth 1:20–21; Ruth 2:1–3", "cross-chapter range"},
+		{"Ruth 1", "Ruth 1:1-10; Ruth 1:11-22", "", "chapter removed in parts"},
+		{"Prov 3:5ff", "Prov 3:8", "Prov 3:5–7; Prov 3:9ff", "ff without verse counts"},
+		{"Ruth 1:20ff", "Ruth 1:21", "Ruth 1:20; Ruth 1:22", "ff with verse counts"},
+		{"Ps 51:0-2", "Ps 51:1", "Ps 51:0; Ps 51:2", "verse zero kept"},
+		{"Ps 51:0-2", "Ps 51:0", "Ps 51:1–2", "verse zero removed"},
+		{"Ps 51", "Ps 51:1-19", "Ps 51:0", "whole chapter keeps verse zero"},
+		{"Ps 51", "Ps 51:0", "Ps 51:1–19", "whole chapter without verse zero"},
+		{"Ruth 1", "Ruth 1:1-22", "", "verse zero not counted without AllowVerseZero"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			a, err := bibleref.ParseList(tc.a, tbl)
+			if err != nil {
+				t.Fatalf("ParseList(%q) failed: %v", tc.a, err)
+			}
+			b, err := bibleref.ParseList(tc.b, tbl)
+			if err != nil {
+				t.Fatalf("ParseList(%q) failed: %v", tc.b, err)
+			}
+			if got := joinRefs(bibleref.DifferenceLists(a, b, tbl)); got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+// TestMerge tests combining overlapping and adjacent references into minimal spans.
+func TestMerge(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected string
+		desc     string
+	}{
+		{"Prov 31:10-20; Prov 31:18-31", "Prov 31:10–31", "overlap"},
+		{"Prov 3:10-12; Prov 3:13-15", "Prov 3:10–15", "adjacency"},
+		{"Prov 3:1-3; Prov 3:10-12", "Prov 3:1–3; Prov 3:10–12", "disjoint"},
+		{"Prov 3:10-12; Prov 4:13-15", "Prov 3:10–12; Prov 4:13–15", "different chapters"},
+		{"Prov 3:5; Matt 1:1; Prov 3:6", "Prov 3:5–6; Matt 1:1", "chapters in order of first appearance"},
+		{"Prov 3:20; Prov 3:1-5", "Prov 3:1–5; Prov 3:20", "spans in verse order"},
+		{"Prov 3:5; Prov 3; Prov 3:30", "Prov 3", "chapter absorbs verses"},
+		{"Prov 3:5,7-9; Prov 3:6", "Prov 3:5–9", "verse list"},
+		{"Prov 3:5a; Prov 3:5; Prov 3:6", "Prov 3:5a; Prov 3:5–6", "verse part kept"},
+		{"Prov 3:5ff; Prov 3", "Prov 3", "following notation absorbed by chapter"},
+		{"Gen 1:30-2:3; Gen 2:1-5", "Gen 1:30–2:3; Gen 2:1–5", "cross-chapter range kept"},
+		{"Gen 1-3; Gen 2:1", "Gen 1–3; Gen 2:1", "chapter range kept"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			refs, err := bibleref.ParseList(tc.input, tbl)
+			if err != nil {
+				t.Fatalf("ParseList(%q) failed: %v", tc.input, err)
+			}
+			if got := joinRefs(bibleref.Merge(refs)); got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+
+	noBook := []bibleref.BibleRef{{Chapter: 1}, {Chapter: 1, Verse: &util.VerseRange{StartVerse: 2}}, {Chapter: 2}}
+	if got := bibleref.Merge(noBook); len(got) != 2 || got[0].Chapter != 1 || got[0].Verse != nil || got[1].Chapter != 2 {
+		t.Errorf("expected references without an OSIS code to be merged, got %v", got)
+	}
+	if got := bibleref.Merge(nil); got != nil {
+		t.Errorf("expected nil for no references, got %v", got)
+	}
+}
+
+// TestParseList_AndInBookName tests that the word "and" inside a book name does not split the
+// list.
+func TestParseList_AndInBookName(t *testing.T) {
+	tbl, err := bibleref.NewTable(append(testBooks(),
+		bibleref.Book{OSIS: "Bel", Name: "Bel and the Dragon", Aliases: []string{"Bel and the Dragon"}, Testament: "AP", Order: 48, Chapters: 1},
+		bibleref.Book{OSIS: "Sus", Name: "Susanna", Aliases: []string{"Susanna"}, Testament: "AP", Order: 47, Chapters: 1},
+	))
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"Bel and the Dragon 3; Sus 4", "Bel 1:3; Sus 1:4"},
+		{"Sus 4 and Bel and the Dragon 3", "Sus 1:4; Bel 1:3"},
+		{"Prov 3:5 and Bel 3", "Prov 3:5; Bel 1:3"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			refs, err := bibleref.ParseList(tc.input, tbl)
+			if err != nil {
+				t.Fatalf("ParseList(%q) failed: %v", tc.input, err)
+			}
+			if got := joinRefs(refs); got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package bibleref
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/julianstephens/canonref/util"
+)
+
+// NextChapter returns a chapter-only reference to the chapter after the one r ends in, e.g.
+// Prov 4 for "Prov 3:5". At the last chapter of a book it rolls over to the first chapter of
+// the next book in canonical order, so Gen 50 is followed by the next book's chapter 1; callers
+// that want to stay within a book can compare the OSIS codes. At the end of the canon it
+// returns nil and an error wrapping ErrEndOfCanon.
+func (r BibleRef) NextChapter(tbl *Table) (*BibleRef, error) {
+	book, err := r.book(tbl)
+	if err != nil {
+		return nil, err
+	}
+	if ch := r.endChapter(); ch < book.Chapters {
+		return &BibleRef{OSIS: book.OSIS, Chapter: ch + 1}, nil
+	}
+	next, ok := adjacentBook(tbl, book.OSIS, 1)
+	if !ok {
+		return nil, endOfCanonError(book.OSIS, book.Chapters)
+	}
+	return &BibleRef{OSIS: next.OSIS, Chapter: 1}, nil
+}
+
+// PreviousChapter returns a chapter-only reference to the chapter before the one r starts in,
+// e.g. Prov 2 for "Prov 3:5". At the first chapter of a book it rolls back to the last chapter
+// of the previous book in canonical order. At the start of the canon it returns nil and an
+// error wrapping ErrEndOfCanon.
+func (r BibleRef) PreviousChapter(tbl *Table) (*BibleRef, error) {
+	book, err := r.book(tbl)
+	if err != nil {
+		return nil, err
+	}
+	if r.Chapter > 1 {
+		return &BibleRef{OSIS: book.OSIS, Chapter: min(r.Chapter-1, book.Chapters)}, nil
+	}
+	prev, ok := adjacentBook(tbl, book.OSIS, -1)
+	if !ok {
+		return nil, endOfCanonError(book.OSIS, 1)
+	}
+	return &BibleRef{OSIS: prev.OSIS, Chapter: prev.Chapters}, nil
+}
+
+// adjacentBook returns the book step places from osis in the order of BooksInOrder, reporting
+// false when there is none.
+func adjacentBook(tbl *Table, osis string, step int) (Book, bool) {
+	books := tbl.BooksInOrder()
+	i := slices.IndexFunc(books, func(b Book) bool { return b.OSIS == osis }) + step
+	if i < 0 || i >= len(books) {
+		return Book{}, false
+	}
+	return books[i], true
+}
+
+// endOfCanonError reports that there is no chapter beyond the given chapter of osis.
+func endOfCanonError(osis string, chapter int) error {
+	return &BibleRefError{
+		Kind:    KindInvalidChapter,
+		Err:     ErrEndOfCanon,
+		Message: util.Ptr(fmt.Sprintf("no chapter beyond %s %d", osis, chapter)),
+	}
+}
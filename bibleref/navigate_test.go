@@ -0,0 +1,63 @@
+package bibleref_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/julianstephens/canonref/bibleref"
+	"github.com/julianstephens/canonref/util"
+)
+
+// TestBibleRef_NextPreviousChapter tests chapter navigation within a book, across book
+// boundaries, and at the ends of the canon.
+func TestBibleRef_NextPreviousChapter(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		ref      bibleref.BibleRef
+		next     string
+		previous string
+		desc     string
+	}{
+		{bibleref.BibleRef{OSIS: "Prov", Chapter: 3, Verse: &util.VerseRange{StartVerse: 5}}, "Prov 4", "Prov 2", "mid-book verse"},
+		{bibleref.BibleRef{OSIS: "Gen", Chapter: 1, EndChapter: util.Ptr(3)}, "Gen 4", "", "chapter range starting at canon start"},
+		{bibleref.BibleRef{OSIS: "Gen", Chapter: 50}, "1Sam 1", "Gen 49", "last chapter of a book"},
+		{bibleref.BibleRef{OSIS: "Ps", Chapter: 1}, "Ps 2", "2Sam 24", "first chapter of a book"},
+		{bibleref.BibleRef{OSIS: "Jude", Chapter: 1}, "Wis 1", "Matt 28", "single-chapter book"},
+		{bibleref.BibleRef{OSIS: "Wis", Chapter: 19}, "", "Wis 18", "canon end"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			next, err := tc.ref.NextChapter(tbl)
+			checkChapterStep(t, "NextChapter", next, err, tc.next)
+			previous, err := tc.ref.PreviousChapter(tbl)
+			checkChapterStep(t, "PreviousChapter", previous, err, tc.previous)
+		})
+	}
+
+	if _, err := (bibleref.BibleRef{OSIS: "Xyz", Chapter: 1}).NextChapter(tbl); !errors.Is(err, bibleref.ErrInvalidOSISCode) {
+		t.Errorf("expected ErrInvalidOSISCode for unknown book, got %v", err)
+	}
+}
+
+// checkChapterStep asserts the result of a chapter navigation call. An empty expected value
+// means the call should fail at the end of the canon.
+func checkChapterStep(t *testing.T, name string, got *bibleref.BibleRef, err error, expected string) {
+	t.Helper()
+	if expected == "" {
+		if got != nil || !errors.Is(err, bibleref.ErrEndOfCanon) {
+			t.Errorf("%s: expected nil and ErrEndOfCanon, got %v, %v", name, got.SafeString(), err)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("%s failed: %v", name, err)
+	}
+	if got.String() != expected {
+		t.Errorf("%s: expected %q, got %q", name, expected, got.String())
+	}
+}
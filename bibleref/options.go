@@ -0,0 +1,154 @@
+package bibleref
+
+// ParseOption configures optional behavior of Parse and ParseDetailed.
+type ParseOption func(*parseConfig)
+
+type parseConfig struct {
+	relativeChapters bool
+	defaultBook      string
+	strictVerseLists bool
+	steppedRanges    bool
+	compactVerses    bool
+	intervals        bool
+	maxInputLength   int
+	retainAlias      bool
+	danglingColon    bool
+	skipValidation   bool
+}
+
+// DefaultMaxInputLength is the longest input, in bytes, that Parse accepts unless
+// WithMaxInputLength sets another limit. It is far longer than any real reference.
+const DefaultMaxInputLength = 1024
+
+func newParseConfig(opts []ParseOption) parseConfig {
+	cfg := parseConfig{maxInputLength: DefaultMaxInputLength}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// WithRelativeChapters enables negative chapter numbers that count back from the end of
+// the book, so "Prov -1" is the last chapter of Proverbs and "Prov -2" the one before it.
+// A relative chapter that would fall before chapter 1 is rejected, as is a relative chapter
+// that starts a chapter or cross-chapter range, such as "Prov -1-3".
+func WithRelativeChapters() ParseOption {
+	return func(cfg *parseConfig) {
+		cfg.relativeChapters = true
+	}
+}
+
+// WithDefaultBook sets the book that a reference without a book token resolves against, so
+// "3:5" parses as a reference to that book. The book is given by its OSIS code. Without this
+// option, a bare chapter and verse is only accepted when the Table contains exactly one book.
+func WithDefaultBook(osis string) ParseOption {
+	return func(cfg *parseConfig) {
+		cfg.defaultBook = osis
+	}
+}
+
+// WithStrictVerseLists makes ParseList reject a verse segment that overlaps or repeats the
+// previous segment of the same chapter, as in "Prov 3:5,5-8", instead of merging the two.
+// Parse likewise rejects a verse list holding overlapping segments.
+func WithStrictVerseLists() ParseOption {
+	return func(cfg *parseConfig) {
+		cfg.strictVerseLists = true
+	}
+}
+
+// WithSteppedRanges enables a step suffix on a verse range, written as a slash and a step
+// after the end verse: "Prov 3:1-9/2" cites every second verse, Prov 3:1,3,5,7,9. The step
+// applies only to a single verse range within one chapter and must be a positive integer.
+// The slash is read as a step only when this option is set.
+func WithSteppedRanges() ParseOption {
+	return func(cfg *parseConfig) {
+		cfg.steppedRanges = true
+	}
+}
+
+// WithCompactVerseSeparator enables the compact notation that writes a lone 'v' in place of
+// the colon between chapter and verse, so "Prov 3v5" is Prov 3:5 and "Prov 3v5-8" is
+// Prov 3:5-8. This is separate from the verse qualifier words, as in "Prov 3 v. 5", which are
+// always accepted.
+func WithCompactVerseSeparator() ParseOption {
+	return func(cfg *parseConfig) {
+		cfg.compactVerses = true
+	}
+}
+
+// WithIntervalNotation enables interval notation for verse ranges, written after the book as
+// two chapter:verse endpoints separated by a comma inside brackets. A square bracket includes
+// its endpoint and a parenthesis excludes it, so "Prov [3:5,3:8]" is Prov 3:5-8,
+// "Prov [3:5,3:8)" is Prov 3:5-7, and "Prov (3:5,3:8)" is Prov 3:6-7. The chapter may be
+// omitted from the end point, as in "Prov [3:5,8)", and an interval may span chapters, as in
+// "Gen [1:30,2:4)". Excluding an endpoint moves it by one verse within its chapter, so an
+// exclusive end at verse 1 is rejected, as is an interval that contains no verses.
+func WithIntervalNotation() ParseOption {
+	return func(cfg *parseConfig) {
+		cfg.intervals = true
+	}
+}
+
+// WithMaxInputLength sets the longest input, in bytes, that Parse accepts, replacing
+// DefaultMaxInputLength. Longer input is rejected with ErrInputTooLong before any parsing is
+// done, which bounds the work a server does for pasted or hostile text. A limit of zero or
+// less disables the check.
+func WithMaxInputLength(n int) ParseOption {
+	return func(cfg *parseConfig) {
+		cfg.maxInputLength = n
+	}
+}
+
+// WithRetainedAlias makes Parse record on the returned BibleRef how its book was written, for
+// MatchedAlias and FormatAsTyped. The recorded spelling does not affect Equal or Compare.
+func WithRetainedAlias() ParseOption {
+	return func(cfg *parseConfig) {
+		cfg.retainAlias = true
+	}
+}
+
+// WithDanglingColon reads a chapter followed by a colon and no verse, as in "Prov 3:", as the
+// chapter-only reference "Prov 3". Such input is otherwise rejected as an empty verse, which
+// suits strict input but not a UI that parses a reference while it is being typed.
+func WithDanglingColon() ParseOption {
+	return func(cfg *parseConfig) {
+		cfg.danglingColon = true
+	}
+}
+
+// WithoutValidation makes Parse check only the structure of a reference, skipping the checks
+// of Validate against the Table, for parsing against a partial Table or deferring book
+// resolution. The book is still resolved through the Table when possible; an unknown book is
+// kept as its normalized token, so "Foo 3:5" parses with OSIS "foo" and then fails Validate.
+// A relative chapter cannot be resolved without the book and is still rejected.
+func WithoutValidation() ParseOption {
+	return func(cfg *parseConfig) {
+		cfg.skipValidation = true
+	}
+}
+
+// FormatOption configures optional behavior of ParseInfo.FormatPreservingInput.
+type FormatOption func(*formatConfig)
+
+type formatConfig struct {
+	preserveZeros bool
+}
+
+func newFormatConfig(opts []FormatOption) formatConfig {
+	var cfg formatConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// WithPreserveInputZeros re-emits the zero padding of each chapter and verse number as it was
+// typed, so "Prov 03:05" is formatted as "Prov 03:05" rather than "Prov 3:5". Padding is only
+// restored when the numbers of the output line up one for one with those of the input; a
+// reference reinterpreted during parsing, such as the single-chapter shorthand "Jude 04",
+// is formatted without padding.
+func WithPreserveInputZeros() FormatOption {
+	return func(cfg *formatConfig) {
+		cfg.preserveZeros = true
+	}
+}
@@ -0,0 +1,121 @@
+package bibleref
+
+import (
+	"fmt"
+
+	"github.com/julianstephens/canonref/util"
+)
+
+// ParseToOrdinalRange parses a reference string and returns the inclusive ordinal bounds of
+// the verses it covers, for range queries against a verse-ordinal column. See
+// BibleRef.OrdinalRange for how ordinals are numbered.
+func ParseToOrdinalRange(s string, tbl *Table) (startOrd, endOrd int64, err error) {
+	ref, err := Parse(s, tbl)
+	if err != nil {
+		return 0, 0, err
+	}
+	return ref.OrdinalRange(tbl)
+}
+
+// OrdinalRange returns the inclusive bounds of the BibleRef as verse ordinals. Ordinals number
+// every verse of the Table consecutively from 1, in canonical book order (by Order, then OSIS
+// code) and then by chapter and verse. In a book with AllowVerseZero, verse 0 of every chapter
+// is numbered before verse 1. A single verse has equal bounds, a chapter-only reference spans
+// its whole chapter, an "ff" range runs to the end of its chapter, and a verse list spans from
+// its lowest to its highest verse. It needs the VerseCounts of the book and of every book
+// before it, and returns an error when they are unavailable.
+func (r BibleRef) OrdinalRange(tbl *Table) (startOrd, endOrd int64, err error) {
+	book, err := r.book(tbl)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	base, err := versesBefore(tbl, book)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	startVerse, endVerse := book.firstVerse(), 0
+	if r.Verse != nil {
+		startVerse, endVerse = r.span()
+		for _, v := range r.Additional {
+			start, end := segmentSpan(v)
+			startVerse, endVerse = min(startVerse, start), max(endVerse, end)
+		}
+		if endVerse == unknownChapterSize {
+			count, ok := book.VersesIn(r.Chapter)
+			if !ok {
+				return 0, 0, missingVerseCount(book.OSIS, r.Chapter)
+			}
+			endVerse = count
+		}
+	} else {
+		count, ok := book.VersesIn(r.endChapter())
+		if !ok {
+			return 0, 0, missingVerseCount(book.OSIS, r.endChapter())
+		}
+		endVerse = count
+	}
+
+	start, err := chapterOrdinal(book, r.Chapter, startVerse)
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err := chapterOrdinal(book, r.endChapter(), endVerse)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return base + start, base + end, nil
+}
+
+// versesBefore returns the total number of verses in the books that precede book in
+// canonical order.
+func versesBefore(tbl *Table, book Book) (int64, error) {
+	var total int64
+	for _, other := range tbl.ByOsis {
+		if other.Order > book.Order || (other.Order == book.Order && other.OSIS >= book.OSIS) {
+			continue
+		}
+		for ch := 1; ch <= other.Chapters; ch++ {
+			count, ok := chapterVerses(other, ch)
+			if !ok {
+				return 0, missingVerseCount(other.OSIS, ch)
+			}
+			total += int64(count)
+		}
+	}
+	return total, nil
+}
+
+// chapterOrdinal returns the 1-based position of verse within book, counting the verses of
+// the chapters before chapter.
+func chapterOrdinal(book Book, chapter, verse int) (int64, error) {
+	ord := int64(verse - book.firstVerse() + 1)
+	for ch := 1; ch < chapter; ch++ {
+		count, ok := chapterVerses(book, ch)
+		if !ok {
+			return 0, missingVerseCount(book.OSIS, ch)
+		}
+		ord += int64(count)
+	}
+	return ord, nil
+}
+
+// chapterVerses returns the number of verses numbered in the chapter, including verse 0 for a
+// book with AllowVerseZero.
+func chapterVerses(book Book, chapter int) (int, bool) {
+	count, ok := book.VersesIn(chapter)
+	if ok && book.AllowVerseZero {
+		count++
+	}
+	return count, ok
+}
+
+func missingVerseCount(osis string, chapter int) error {
+	return &BibleRefError{
+		Kind:    KindMissingData,
+		Err:     ErrVerseCountsUnavailable,
+		Message: util.Ptr(fmt.Sprintf("no verse count for %s %d", osis, chapter)),
+	}
+}
@@ -0,0 +1,65 @@
+package bibleref_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/julianstephens/canonref/bibleref"
+)
+
+// TestParseToOrdinalRange tests the ordinal bounds of a verse, a range, and a chapter-only
+// reference, counting the verses of earlier books.
+func TestParseToOrdinalRange(t *testing.T) {
+	tbl, err := bibleref.NewTable([]bibleref.Book{
+		{OSIS: "Lev", Name: "Leviticus", Aliases: []string{"leviticus"}, Testament: "OT", Order: 3, Chapters: 2, VerseCounts: []int{2, 5}},
+		{OSIS: "Gen", Name: "Genesis", Aliases: []string{"genesis"}, Testament: "OT", Order: 1, Chapters: 2, VerseCounts: []int{3, 2}},
+		{OSIS: "Exod", Name: "Exodus", Aliases: []string{"exodus"}, Testament: "OT", Order: 2, Chapters: 1, VerseCounts: []int{4}},
+		{OSIS: "Ps", Name: "Psalms", Aliases: []string{"psalms"}, Testament: "OT", Order: 19, Chapters: 2, VerseCounts: []int{3, 4}, AllowVerseZero: true},
+	})
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input      string
+		start, end int64
+		desc       string
+	}{
+		{"Gen 1:1", 1, 1, "first verse"},
+		{"Exod 1:2", 7, 7, "single verse"},
+		{"Lev 2:1-3", 12, 14, "range"},
+		{"Lev 2", 12, 16, "chapter-only"},
+		{"Gen 1:3-2:1", 3, 4, "cross-chapter range"},
+		{"Lev 2:4,1-2", 12, 15, "verse list"},
+		{"Lev 2:3ff", 14, 16, "ff"},
+		{"Ps 1:0", 17, 17, "verse zero"},
+		{"Ps 2:0–2", 21, 23, "range from verse zero"},
+		{"Ps 2", 21, 25, "chapter-only with verse zero"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			start, end, err := bibleref.ParseToOrdinalRange(tc.input, tbl)
+			if err != nil {
+				t.Fatalf("ParseToOrdinalRange(%q) failed: %v", tc.input, err)
+			}
+			if start != tc.start || end != tc.end {
+				t.Errorf("expected [%d, %d], got [%d, %d]", tc.start, tc.end, start, end)
+			}
+		})
+	}
+
+	t.Run("missing verse counts", func(t *testing.T) {
+		sparse, err := bibleref.NewTable(testBooks())
+		if err != nil {
+			t.Fatalf("NewTable failed: %v", err)
+		}
+		if _, _, err := bibleref.ParseToOrdinalRange("Prov 3:5", sparse); !errors.Is(err, bibleref.ErrVerseCountsUnavailable) {
+			t.Errorf("expected ErrVerseCountsUnavailable, got %v", err)
+		}
+	})
+
+	if _, _, err := bibleref.ParseToOrdinalRange("Nope 1:1", tbl); err == nil {
+		t.Error("expected an unknown book to be rejected")
+	}
+}
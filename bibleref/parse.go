@@ -1,19 +1,96 @@
 package bibleref
 
 import (
+	"cmp"
 	"fmt"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/julianstephens/canonref/util"
 )
 
+// ParseInfo describes how ParseDetailed interpreted a reference string.
+type ParseInfo struct {
+	// Ref is the parsed and validated reference.
+	Ref *BibleRef
+	// SingleChapterShorthand reports that the book has only one chapter and the number
+	// or numbers following it were read as verses of chapter 1, e.g. "Jude 4" as Jude 1:4
+	// and "Jude 3-5" as Jude 1:3–5.
+	SingleChapterShorthand bool
+	// RawChapterVerse is the chapter/verse portion of the input as it was tokenized.
+	RawChapterVerse string
+	// RawBook is the book portion of the input with its original spelling and casing,
+	// e.g. "PROVERBS" for "PROVERBS 3:5".
+	RawBook string
+	// Partial holds the parsed chapter and verse when the book could not be resolved,
+	// with OSIS set to the unrecognized book token as normalized by NormalizeAlias.
+	// It is nil otherwise.
+	Partial *BibleRef
+	// MatchedAlias is the normalized alias or OSIS code that resolved the book, e.g. "pro"
+	// for "Pro. 3:5" or "proverbs" for "PROVERBS 3:5".
+	MatchedAlias string
+	// Marker holds the typographic note markers that trailed the reference and were removed
+	// before parsing, e.g. "†" for "Prov 3:5†". It is empty when there were none.
+	Marker string
+}
+
 // Parse parses a reference string into a BibleRef struct using the provided Table for book lookups.
 // It returns a BibleRefError if parsing fails or if the reference is invalid.
-func Parse(s string, tbl *Table) (*BibleRef, error) {
-	parseResult, err := doParse(s, tbl)
+// Doubled chapter-verse separators are tolerated, so "Prov 3::5" parses as Prov 3:5, and a
+// single comma is accepted as the separator when no colon is present ("Prov 3,5"). Trailing
+// note markers such as a dagger or asterisk are ignored, so "Prov 3:5†" parses as Prov 3:5;
+// ParseDetailed reports them in ParseInfo.Marker.
+// Full-width, superscript, and Arabic-Indic digits are read as their ASCII equivalents.
+// A verse may be followed by a part letter from "a" to "d", as in "Rom 3:23a" or "Matt 5:3a-5b".
+// Overlapping segments of a verse list are merged, so "Prov 3:5,5-8" parses as Prov 3:5–8.
+//
+// A reference may omit the book when the Table holds a single book or a default is set with
+// WithDefaultBook, so "3:5" resolves against that book.
+//
+// Optional behavior can be enabled with ParseOption values such as WithRelativeChapters.
+func Parse(s string, tbl *Table, opts ...ParseOption) (*BibleRef, error) {
+	info, err := ParseDetailed(s, tbl, opts...)
 	if err != nil {
+		return nil, err
+	}
+
+	return info.Ref, nil
+}
+
+// ParseDetailed parses a reference string like Parse, but also reports how the
+// reference was interpreted. It returns a BibleRefError if parsing fails or if the
+// reference is invalid.
+//
+// For single-chapter books (e.g. Jude), a number greater than 1 without a verse is
+// read as a verse of chapter 1, so "Jude 4" parses as Jude 1:4 and sets
+// SingleChapterShorthand. Likewise a dashed pair is read as a verse range of chapter 1, so
+// "Jude 3-5" parses as Jude 1:3–5 rather than a chapter range. "Jude 1" remains a
+// chapter-only reference to the whole book.
+//
+// Input longer than DefaultMaxInputLength, or the limit set with WithMaxInputLength, is
+// rejected with ErrInputTooLong without being parsed or echoed in the error message.
+//
+// On failure the returned ParseInfo is nil, except when the chapter and verse parsed but the
+// book is unknown: then the error is returned alongside a ParseInfo whose Partial field holds
+// the parsed numbers, so callers can suggest a correction for the book alone.
+func ParseDetailed(s string, tbl *Table, opts ...ParseOption) (*ParseInfo, error) {
+	cfg := newParseConfig(opts)
+	if cfg.maxInputLength > 0 && len(s) > cfg.maxInputLength {
 		return nil, &BibleRefError{
+			Kind:    KindParse,
+			Err:     ErrInputTooLong,
+			Message: util.Ptr(fmt.Sprintf("input of %d bytes exceeds the limit of %d", len(s), cfg.maxInputLength)),
+		}
+	}
+
+	info, err := doParse(s, tbl, cfg)
+	if err != nil {
+		return info, &BibleRefError{
 			Kind:    KindParse,
 			Err:     ErrBibleRefParseFailed,
 			Message: util.Ptr(fmt.Sprintf("failed to parse reference string: %s", s)),
@@ -21,12 +98,56 @@ func Parse(s string, tbl *Table) (*BibleRef, error) {
 		}
 	}
 
-	return parseResult, nil
+	return info, nil
 }
 
+// FormatPreservingInput returns the parsed reference with the book exactly as it was written
+// in the input and the chapter/verse portion normalized, e.g. "PROVERBS 3:5–8" for
+// "PROVERBS 3:5-8". It returns "" if info is nil or has no parsed reference.
+// Options such as WithPreserveInputZeros carry more of the input into the output.
+func (info *ParseInfo) FormatPreservingInput(opts ...FormatOption) string {
+	if info == nil || info.Ref == nil {
+		return ""
+	}
+	cv := info.Ref.chapterVerse(":")
+	if newFormatConfig(opts).preserveZeros {
+		cv = padLikeInput(cv, info.RawChapterVerse)
+	}
+	return fmt.Sprintf("%s %s", info.RawBook, cv)
+}
+
+// padLikeInput pads each number in the formatted chapter/verse string cv with leading zeros
+// to the width of the matching number in raw, the chapter/verse portion of the input. It
+// returns cv unchanged if the two do not hold the same numbers in the same order.
+func padLikeInput(cv, raw string) string {
+	in := digitRun.FindAllString(raw, -1)
+	out := digitRun.FindAllStringIndex(cv, -1)
+	if len(out) != len(in) {
+		return cv
+	}
+	for i, loc := range out {
+		if strings.TrimLeft(in[i], "0") != strings.TrimLeft(cv[loc[0]:loc[1]], "0") {
+			return cv
+		}
+	}
+
+	var b strings.Builder
+	prev := 0
+	for i, loc := range out {
+		b.WriteString(cv[prev:loc[0]])
+		b.WriteString(in[i])
+		prev = loc[1]
+	}
+	b.WriteString(cv[prev:])
+	return b.String()
+}
+
+// digitRun matches a run of ASCII digits.
+var digitRun = regexp.MustCompile(`[0-9]+`)
+
 // MustParse is a helper function that calls Parse and panics if there is an error.
-func MustParse(s string, tbl *Table) *BibleRef {
-	ref, err := Parse(s, tbl)
+func MustParse(s string, tbl *Table, opts ...ParseOption) *BibleRef {
+	ref, err := Parse(s, tbl, opts...)
 	if err != nil {
 		panic(fmt.Sprintf("failed to parse reference string: %s, error: %v", s, err))
 	}
@@ -34,21 +155,37 @@ func MustParse(s string, tbl *Table) *BibleRef {
 	return ref
 }
 
-func doParse(s string, tbl *Table) (*BibleRef, error) {
-	ref, err := parseRefString(s, tbl)
+// IsCanonical reports whether s is already in canonical form, the rendering of String, such
+// as "Prov 31:10–31". Input that parses to the same reference but is written differently,
+// such as "proverbs 31:10-31", is not canonical. It returns the parse error if s is not a
+// valid reference.
+func IsCanonical(s string, tbl *Table) (bool, error) {
+	ref, err := Parse(s, tbl)
 	if err != nil {
-		return nil, err
+		return false, err
+	}
+	return s == ref.String(), nil
+}
+
+func doParse(s string, tbl *Table, cfg parseConfig) (*ParseInfo, error) {
+	info, err := parseRefString(s, tbl, cfg)
+	if err != nil {
+		return info, err
 	}
 
-	if err := ref.Validate(tbl); err != nil {
+	if cfg.skipValidation {
+		return info, nil
+	}
+	if err := info.Ref.Validate(tbl); err != nil {
 		return nil, err
 	}
 
-	return ref, nil
+	return info, nil
 }
 
-func parseRefString(s string, tbl *Table) (*BibleRef, error) {
-	s = strings.TrimSpace(s)
+func parseRefString(s string, tbl *Table, cfg parseConfig) (*ParseInfo, error) {
+	s, marker := cutNoteMarker(util.NormalizeDigits(strings.TrimSpace(s)))
+	s = expandOSISForm(s)
 	if s == "" {
 		return nil, &BibleRefError{
 			Kind:    KindParse,
@@ -57,7 +194,12 @@ func parseRefString(s string, tbl *Table) (*BibleRef, error) {
 		}
 	}
 
-	fields := strings.Fields(s)
+	fields := splitAttachedTail(joinVerseList(joinSpacedSeparators(applyQualifiers(strings.Fields(s)))))
+	if len(fields) == 1 && startsWithDigit(fields[0]) {
+		if osis, ok := defaultBook(tbl, cfg); ok {
+			fields = []string{osis, fields[0]}
+		}
+	}
 	if len(fields) < 2 {
 		return nil, &BibleRefError{
 			Kind:    KindParse,
@@ -66,104 +208,526 @@ func parseRefString(s string, tbl *Table) (*BibleRef, error) {
 		}
 	}
 
+	if err := checkStrayBook(fields); err != nil {
+		return nil, err
+	}
+
 	bookPart := strings.Join(fields[:len(fields)-1], " ")
-	bookStr := NormalizeAlias(bookPart)
-	chapterVerseStr, err := parseTail(fields[len(fields)-1])
+	bookStr := NormalizeAlias(splitRomanPrefix(bookPart))
+	tail := fields[len(fields)-1]
+	relative := cfg.relativeChapters && strings.HasPrefix(tail, util.Hyphen)
+	rangeStr, step, err := cutStep(util.If(relative, tail[len(util.Hyphen):], tail), cfg)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.compactVerses {
+		rangeStr = replaceCompactVerseSeparator(rangeStr)
+	}
+	if chapter := strings.TrimRight(rangeStr, ":"); cfg.danglingColon && chapter != rangeStr && isDigits(chapter) {
+		rangeStr = chapter
+	}
+	if cfg.intervals && (strings.HasPrefix(rangeStr, "[") || strings.HasPrefix(rangeStr, "(")) {
+		if rangeStr, err = intervalTail(rangeStr); err != nil {
+			return nil, err
+		}
+	}
+	chapterVerseStr, err := parseTail(rangeStr)
 	if err != nil {
 		return nil, err
 	}
 
-	bookOsis, ok := tbl.ByAlias[bookStr]
-	if !ok {
-		bookOsis = bookStr
+	ref, err := parseChapterVerse(chapterVerseStr)
+	if err != nil {
+		return nil, err
+	}
+	if step > 0 {
+		if err := applyStep(ref, step); err != nil {
+			return nil, err
+		}
+	}
+	if len(ref.Additional) > 0 {
+		if err := mergeVerseList(ref, cfg.strictVerseLists); err != nil {
+			return nil, err
+		}
 	}
 
-	book, ok := tbl.ByOsis[bookOsis]
-	if !ok {
+	info := &ParseInfo{RawChapterVerse: tail, RawBook: bookPart, Marker: marker}
+	book, alias, ok := resolveBookAlias(tbl, bookStr)
+	if ref.Verse != nil && ref.Verse.StartVerse < book.firstVerse() {
 		return nil, &BibleRefError{
+			Kind:    KindInvalidVerse,
+			Err:     ErrInvalidVerse,
+			Message: util.Ptr(fmt.Sprintf("invalid verse number: %d", ref.Verse.StartVerse)),
+		}
+	}
+	if !ok && cfg.skipValidation && !relative {
+		ref.OSIS = bookStr
+		info.Ref = ref
+		return info, nil
+	}
+	if !ok {
+		ref.OSIS = bookStr
+		info.Partial = ref
+		return info, &BibleRefError{
 			Kind:    KindUnknownBook,
 			Err:     ErrInvalidOSISCode,
 			Message: util.Ptr(fmt.Sprintf("unknown book: %s", bookStr)),
 		}
 	}
 
-	chapter, verseRange, err := parseChapterVerse(chapterVerseStr)
-	if err != nil {
-		return nil, err
+	ref.OSIS = book.OSIS
+	info.MatchedAlias = alias
+	if cfg.retainAlias {
+		ref.matchedAlias, ref.typedBook = alias, bookPart
 	}
-	if verseRange != nil && verseRange.StartVerse < 1 {
-		return nil, &BibleRefError{
-			Kind:    KindInvalidVerse,
-			Err:     ErrInvalidVerse,
-			Message: util.Ptr(fmt.Sprintf("invalid verse number: %d", verseRange.StartVerse)),
+	if relative {
+		if ref.EndChapter != nil {
+			return nil, &BibleRefError{
+				Kind:    KindInvalidChapter,
+				Err:     ErrInvalidChapter,
+				Message: util.Ptr(fmt.Sprintf("relative chapter %s cannot start a range", tail)),
+			}
+		}
+		ref.Chapter = book.Chapters - ref.Chapter + 1
+		if ref.Chapter < 1 {
+			return nil, &BibleRefError{
+				Kind:    KindInvalidChapter,
+				Err:     ErrInvalidChapter,
+				Message: util.Ptr(fmt.Sprintf("relative chapter %s is out of range for book %s", tail, book.Name)),
+			}
 		}
 	}
+	fillFollowing(ref, book)
 
-	ref := &BibleRef{
-		OSIS:    book.OSIS,
-		Chapter: chapter,
-		Verse:   verseRange,
+	if book.Chapters == 1 && ref.Verse == nil && ref.EndChapter == nil && ref.Chapter > 1 {
+		ref.Verse = &util.VerseRange{StartVerse: ref.Chapter}
+		ref.Chapter = 1
+		info.SingleChapterShorthand = true
 	}
-	if err := ref.Validate(tbl); err != nil {
+	if book.Chapters == 1 && ref.Verse == nil && ref.EndChapter != nil {
+		ref.Verse = &util.VerseRange{StartVerse: ref.Chapter, EndVerse: ref.EndChapter}
+		ref.Chapter = 1
+		ref.EndChapter = nil
+		info.SingleChapterShorthand = true
+	}
+
+	info.Ref = ref
+	if cfg.skipValidation {
+		return info, nil
+	}
+	if err := info.Ref.Validate(tbl); err != nil {
 		return nil, err
 	}
 
-	return ref, nil
+	return info, nil
+}
+
+// noteMarkers lists the typographic glyphs that may trail a reference to point to a note.
+const noteMarkers = "†‡*§"
+
+// cutNoteMarker removes any note markers from the end of s, returning the trimmed reference
+// and the markers, so "Prov 3:5†" yields "Prov 3:5" and "†".
+func cutNoteMarker(s string) (string, string) {
+	trimmed := strings.TrimRight(s, noteMarkers)
+	return strings.TrimSpace(trimmed), s[len(trimmed):]
+}
+
+// expandOSISForm rewrites the period-delimited OSIS form produced by FormatOSIS, such as
+// "Prov.31.10–31" or "Gen.1.31–2.3", into the human form "Prov 31:10–31" that the rest of the
+// parser reads. The OSIS form starts at the first period that follows a letter and precedes a
+// digit, and is recognized only when the rest of the string holds nothing but digits, periods,
+// commas, and range dashes. Any other string, including an abbreviation such as "Prov. 3:5",
+// is returned unchanged.
+func expandOSISForm(s string) string {
+	for i := 1; i+1 < len(s); i++ {
+		if s[i] != '.' || !unicode.IsLetter(rune(s[i-1])) || !startsWithDigit(s[i+1:]) {
+			continue
+		}
+		rest := NormalizeVerseRange(s[i+1:])
+		for _, c := range rest {
+			if (c < '0' || c > '9') && c != '.' && c != ',' && string(c) != util.EnDash {
+				return s
+			}
+		}
+		return s[:i] + " " + strings.ReplaceAll(rest, ".", ":")
+	}
+	return s
+}
+
+// defaultBook returns the OSIS code of the book that a reference without a book token refers
+// to: the book set by WithDefaultBook, or else the only book in a single-book Table.
+func defaultBook(tbl *Table, cfg parseConfig) (string, bool) {
+	if cfg.defaultBook != "" {
+		return cfg.defaultBook, true
+	}
+	if len(tbl.ByOsis) == 1 {
+		for osis := range tbl.ByOsis {
+			return osis, true
+		}
+	}
+	return "", false
+}
+
+// resolveBook looks up a normalized book token, first as an alias and then as an OSIS code.
+// The OSIS comparison is case-insensitive, so "1sam" finds "1Sam" even when the Table has no
+// alias entry for it. If neither matches and the token begins with the article "the", the lookup is retried
+// without it, so "the proverbs" finds Proverbs even when that is not a listed alias. An alias
+// that itself begins with "the" always takes precedence. A numeric prefix matches an alias
+// with or without a space after it, so "1kings" finds the alias "1 kings" and vice versa.
+func resolveBook(tbl *Table, bookStr string) (Book, bool) {
+	book, _, ok := resolveBookAlias(tbl, bookStr)
+	return book, ok
+}
+
+// resolveBookAlias is resolveBook, also returning the normalized alias or OSIS code that
+// matched, e.g. "1 kings" for the token "1kings".
+func resolveBookAlias(tbl *Table, bookStr string) (Book, string, bool) {
+	if book, ok := tbl.ByOsis[tbl.ByAlias[bookStr]]; ok {
+		return book, bookStr, true
+	}
+	if book, ok := findOSISFold(tbl, bookStr); ok {
+		return book, bookStr, true
+	}
+	if alt, found := togglePrefixSpace(bookStr); found {
+		if book, ok := tbl.ByOsis[tbl.ByAlias[alt]]; ok {
+			return book, alt, true
+		}
+	}
+	if rest, found := strings.CutPrefix(bookStr, "the "); found {
+		return resolveBookAlias(tbl, strings.TrimSpace(rest))
+	}
+	return Book{}, "", false
+}
+
+// togglePrefixSpace returns the book token with the space after its numeric prefix added or
+// removed, e.g. "1kings" for "1 kings" and "1 kings" for "1kings". It returns false if the
+// token has no numeric prefix followed by a letter.
+func togglePrefixSpace(bookStr string) (string, bool) {
+	i := 0
+	for i < len(bookStr) && bookStr[i] >= '0' && bookStr[i] <= '9' {
+		i++
+	}
+	if i == 0 || i == len(bookStr) {
+		return "", false
+	}
+
+	if rest, found := strings.CutPrefix(bookStr[i:], " "); found {
+		if rest == "" || !unicode.IsLetter(rune(rest[0])) {
+			return "", false
+		}
+		return bookStr[:i] + rest, true
+	}
+	if !unicode.IsLetter(rune(bookStr[i])) {
+		return "", false
+	}
+	return bookStr[:i] + " " + bookStr[i:], true
 }
 
-func parseChapterVerse(s string) (int, *util.VerseRange, error) {
+// splitRomanPrefix separates a roman numeral book prefix written directly before a
+// capitalized name, e.g. "IIIJohn" becomes "III John", so that NormalizeAlias can read it
+// as a number. Only one to three uppercase I's followed by an uppercase and then a lowercase
+// letter count as a prefix, which leaves names such as "Isaiah" and "ISA" untouched.
+func splitRomanPrefix(bookPart string) string {
+	i := 0
+	for i < len(bookPart) && i < 3 && bookPart[i] == 'I' {
+		i++
+	}
+	if i == 0 || i+1 >= len(bookPart) {
+		return bookPart
+	}
+	if !unicode.IsUpper(rune(bookPart[i])) || !unicode.IsLower(rune(bookPart[i+1])) {
+		return bookPart
+	}
+	return bookPart[:i] + " " + bookPart[i:]
+}
+
+// findOSISFold returns the Book whose normalized OSIS code equals the normalized token. Tables
+// built by NewTable index the normalized codes; a Table built as a literal is scanned.
+func findOSISFold(tbl *Table, bookStr string) (Book, bool) {
+	if book, ok := tbl.ByOsis[bookStr]; ok {
+		return book, true
+	}
+	if tbl.foldedOSIS != nil {
+		book, ok := tbl.ByOsis[tbl.foldedOSIS[bookStr]]
+		return book, ok
+	}
+	for osis, book := range tbl.ByOsis {
+		if NormalizeAlias(osis) == bookStr {
+			return book, true
+		}
+	}
+	return Book{}, false
+}
+
+// parseChapterVerse parses a normalized chapter/verse tail such as "3", "3:5", "3:5–8", the
+// verse list "3:5,7–9", the cross-chapter form "1:30–2:3", or the chapter range "1–3" into a
+// BibleRef without an OSIS code.
+func parseChapterVerse(s string) (*BibleRef, error) {
 	parts := strings.Split(s, ":")
 	if len(parts) == 0 {
-		return 0, nil, &BibleRefError{
+		return nil, &BibleRefError{
 			Kind:    KindParse,
 			Err:     ErrBibleRefParseFailed,
 			Message: util.Ptr("chapter and verse string must contain at least a chapter"),
 		}
 	}
+	if len(parts) == 3 {
+		return parseCrossChapter(parts)
+	}
 	if len(parts) > 2 {
-		return 0, nil, &BibleRefError{
+		return nil, &BibleRefError{
 			Kind:    KindParse,
 			Err:     ErrBibleRefParseFailed,
 			Message: util.Ptr("chapter and verse string must contain at most one colon"),
 		}
 	}
 
-	chapterStr := parts[0]
-	chapter, err := strconv.Atoi(chapterStr)
+	if startStr, endStr, found := strings.Cut(parts[0], util.EnDash); found && len(parts) == 1 {
+		return parseChapterRange(startStr, endStr)
+	}
+
+	chapter, err := parseChapterNumber(parts[0])
 	if err != nil {
-		return 0, nil, &BibleRefError{
-			Kind:    KindInvalidChapter,
-			Err:     ErrInvalidChapter,
-			Message: util.Ptr(fmt.Sprintf("invalid chapter: %s", chapterStr)),
-			Cause:   err,
-		}
+		return nil, err
 	}
 
 	if len(parts) == 1 {
-		return chapter, nil, nil
+		return &BibleRef{Chapter: chapter}, nil
 	}
 
-	verseStr := NormalizeVerseRange(parts[1])
+	ref := &BibleRef{Chapter: chapter}
+	for i, seg := range strings.Split(parts[1], ",") {
+		verseRange, err := parseVerseSegment(seg)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			ref.Verse = verseRange
+			continue
+		}
+		ref.Additional = append(ref.Additional, *verseRange)
+	}
+	return ref, nil
+}
+
+// parseVerseSegment parses a single verse or verse range such as "5" or "7–9". Verses may
+// carry a part letter, as in "3a" or "3a–5b".
+func parseVerseSegment(s string) (*util.VerseRange, error) {
+	verseStr := NormalizeVerseRange(s)
 
+	if numStr, following := cutFollowing(verseStr); following != "" {
+		return parseFollowing(numStr, following)
+	}
 	if strings.Contains(verseStr, util.EnDash) {
 		verseParts := strings.Split(verseStr, util.EnDash)
-		verseRange, err := parseVerseRange(verseStr, verseParts)
-		if err != nil {
-			return 0, nil, err
+		return parseVerseRange(verseStr, verseParts)
+	}
+
+	startVerse, part, err := parseVerseNumber(verseStr)
+	if err != nil {
+		return nil, &BibleRefError{
+			Kind:    KindInvalidVerse,
+			Err:     ErrInvalidVerse,
+			Message: util.Ptr(fmt.Sprintf("invalid verse: %s", verseStr)),
+			Cause:   err,
 		}
-		return chapter, verseRange, nil
-	} else {
-		startVerse, err := strconv.Atoi(verseStr)
-		if err != nil {
-			return 0, nil, &BibleRefError{
+	}
+	return &util.VerseRange{StartVerse: startVerse, StartPart: part}, nil
+}
+
+// mergeVerseList merges each segment of ref's verse list that overlaps an earlier segment into
+// that segment, so "3:5,5–8" becomes "3:5–8" and no verse is cited twice. Segments keep the
+// order they were cited in. Segments with a verse part or the "f" or "ff" notation are never
+// merged. With strict set, an overlap is reported as an error instead.
+func mergeVerseList(ref *BibleRef, strict bool) error {
+	var kept []util.VerseRange
+	for _, seg := range append([]util.VerseRange{*ref.Verse}, ref.Additional...) {
+		i := slices.IndexFunc(kept, func(k util.VerseRange) bool { return segmentsOverlap(k, seg) })
+		if i < 0 {
+			kept = append(kept, seg)
+			continue
+		}
+		if strict {
+			return &BibleRefError{
 				Kind:    KindInvalidVerse,
 				Err:     ErrInvalidVerse,
-				Message: util.Ptr(fmt.Sprintf("invalid verse: %s", verseStr)),
-				Cause:   err,
+				Message: util.Ptr(fmt.Sprintf("verse segment %s overlaps %s", seg.String(), kept[i].String())),
 			}
 		}
-		return chapter, &util.VerseRange{StartVerse: startVerse}, nil
+		kept[i] = mergeSegments(kept[i], seg)
+		for j := i + 1; j < len(kept); {
+			if segmentsOverlap(kept[i], kept[j]) {
+				kept[i] = mergeSegments(kept[i], kept[j])
+				kept = slices.Delete(kept, j, j+1)
+				j = i + 1
+				continue
+			}
+			j++
+		}
+	}
+	ref.Verse = &kept[0]
+	ref.Additional = nil
+	if len(kept) > 1 {
+		ref.Additional = kept[1:]
+	}
+	return nil
+}
+
+// segmentsOverlap reports whether two plain verse segments, without verse parts or the "f" or
+// "ff" notation, share at least one verse. Malformed segments, ending before they start,
+// never overlap, so that Validate still reports them.
+func segmentsOverlap(a, b util.VerseRange) bool {
+	plain := func(v util.VerseRange) bool {
+		start, end := segmentSpan(v)
+		return v.StartPart == "" && v.EndPart == "" && v.Following == "" && end >= start
+	}
+	if !plain(a) || !plain(b) {
+		return false
+	}
+	aStart, aEnd := segmentSpan(a)
+	bStart, bEnd := segmentSpan(b)
+	return aStart <= bEnd && bStart <= aEnd
+}
+
+// mergeSegments returns the verse segment covering both a and b, which must overlap.
+func mergeSegments(a, b util.VerseRange) util.VerseRange {
+	aStart, aEnd := segmentSpan(a)
+	bStart, bEnd := segmentSpan(b)
+	return *verseSpanRef("", 0, min(aStart, bStart), max(aEnd, bEnd)).Verse
+}
+
+// cutFollowing splits the "f" or "ff" notation, optionally followed by a period, from the end
+// of a verse, returning the verse and the notation, e.g. "28" and "ff" for "28ff.". It returns
+// s and "" when there is no notation.
+func cutFollowing(s string) (string, string) {
+	t := strings.TrimSuffix(s, ".")
+	for _, marker := range []string{util.FollowingVerses, util.FollowingVerse} {
+		if rest, ok := strings.CutSuffix(t, marker); ok && rest != "" {
+			return rest, marker
+		}
+	}
+	return s, ""
+}
+
+// parseFollowing parses a verse written with the "f" or "ff" notation, given the verse and
+// the notation. For "f" the range ends at the next verse; for "ff" EndVerse is left for
+// fillFollowing once the book is known.
+func parseFollowing(s, following string) (*util.VerseRange, error) {
+	verse, part, err := parseVerseNumber(s)
+	if err != nil || strings.Contains(s, util.EnDash) {
+		return nil, &BibleRefError{
+			Kind:    KindInvalidVerse,
+			Err:     ErrInvalidVerse,
+			Message: util.Ptr(fmt.Sprintf("invalid verse before %q: %s", following, s)),
+			Cause:   err,
+		}
 	}
+	v := &util.VerseRange{StartVerse: verse, StartPart: part, Following: following}
+	if following == util.FollowingVerse {
+		v.EndVerse = util.Ptr(verse + 1)
+	}
+	return v, nil
+}
+
+// fillFollowing ends each "ff" segment of ref at the last verse of its chapter when the book
+// has a verse count for it and that verse follows the start verse.
+func fillFollowing(ref *BibleRef, book Book) {
+	count, ok := book.VersesIn(ref.Chapter)
+	if !ok || ref.Verse == nil {
+		return
+	}
+	fill := func(v *util.VerseRange) {
+		if v.Following == util.FollowingVerses && v.EndVerse == nil && count > v.StartVerse {
+			v.EndVerse = util.Ptr(count)
+		}
+	}
+	fill(ref.Verse)
+	for i := range ref.Additional {
+		fill(&ref.Additional[i])
+	}
+}
+
+// parseVerseNumber parses a verse number with an optional trailing verse part, a single
+// lowercase letter from "a" to "d", so "23a" yields 23 and "a".
+func parseVerseNumber(s string) (int, string, error) {
+	numStr, part := s, ""
+	if n := len(s); n > 1 && unicode.IsLetter(rune(s[n-1])) {
+		numStr, part = s[:n-1], s[n-1:]
+		if !util.IsVersePart(part) {
+			return 0, "", fmt.Errorf("verse part must be a letter from a to d, got %q", part)
+		}
+	}
+	verse, err := strconv.Atoi(numStr)
+	if err != nil {
+		return 0, "", err
+	}
+	return verse, part, nil
+}
+
+// parseCrossChapter parses a verse range that ends in a later chapter, given the tail split
+// on colons, e.g. ["1", "30–2", "3"] for "1:30–2:3". A range that ends in the same chapter
+// it starts in is returned as an ordinary verse range.
+func parseCrossChapter(parts []string) (*BibleRef, error) {
+	startVerseStr, endChapterStr, ok := strings.Cut(NormalizeVerseRange(parts[1]), util.EnDash)
+	if !ok {
+		return nil, &BibleRefError{
+			Kind:    KindParse,
+			Err:     ErrBibleRefParseFailed,
+			Message: util.Ptr("chapter and verse string must contain at most one colon outside a cross-chapter range"),
+		}
+	}
+
+	chapter, err := parseChapterNumber(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	endChapter, err := parseChapterNumber(endChapterStr)
+	if err != nil {
+		return nil, err
+	}
+
+	verseRange, err := parseVerseRange(parts[1]+":"+parts[2], []string{startVerseStr, NormalizeVerseRange(parts[2])})
+	if err != nil {
+		return nil, err
+	}
+
+	ref := &BibleRef{Chapter: chapter, Verse: verseRange}
+	if endChapter != chapter {
+		ref.EndChapter = &endChapter
+	}
+	return ref, nil
+}
+
+// parseChapterRange parses the chapters of a chapter range such as "1–3". A range that starts
+// and ends in the same chapter is the chapter itself.
+func parseChapterRange(startStr, endStr string) (*BibleRef, error) {
+	chapter, err := parseChapterNumber(startStr)
+	if err != nil {
+		return nil, err
+	}
+	endChapter, err := parseChapterNumber(endStr)
+	if err != nil {
+		return nil, err
+	}
+
+	ref := &BibleRef{Chapter: chapter}
+	if endChapter != chapter {
+		ref.EndChapter = &endChapter
+	}
+	return ref, nil
+}
+
+func parseChapterNumber(s string) (int, error) {
+	chapter, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, &BibleRefError{
+			Kind:    KindInvalidChapter,
+			Err:     ErrInvalidChapter,
+			Message: util.Ptr(fmt.Sprintf("invalid chapter: %s", s)),
+			Cause:   err,
+		}
+	}
+	return chapter, nil
 }
 
 func parseVerseRange(s string, parts []string) (*util.VerseRange, error) {
@@ -175,7 +739,7 @@ func parseVerseRange(s string, parts []string) (*util.VerseRange, error) {
 		}
 	}
 
-	startVerse, err := strconv.Atoi(parts[0])
+	startVerse, startPart, err := parseVerseNumber(parts[0])
 	if err != nil {
 		return nil, &BibleRefError{
 			Kind:    KindInvalidVerse,
@@ -185,7 +749,7 @@ func parseVerseRange(s string, parts []string) (*util.VerseRange, error) {
 		}
 	}
 
-	endVerse, err := strconv.Atoi(parts[1])
+	endVerse, endPart, err := parseVerseNumber(parts[1])
 	if err != nil {
 		return nil, &BibleRefError{
 			Kind:    KindInvalidVerse,
@@ -195,10 +759,27 @@ func parseVerseRange(s string, parts []string) (*util.VerseRange, error) {
 		}
 	}
 
-	return &util.VerseRange{StartVerse: startVerse, EndVerse: &endVerse}, nil
+	return &util.VerseRange{StartVerse: startVerse, EndVerse: &endVerse, StartPart: startPart, EndPart: endPart}, nil
 }
 
+// parseTail validates the chapter/verse tail of a reference and normalizes its verse part.
+// A run of repeated colons, a common typo, is collapsed into a single separator so that
+// "3::5" is read as "3:5".
+//
+// The chapter-verse separator is inferred from the tail: when it contains no colon and a
+// single comma directly follows the chapter number, as in the continental style "3,5" or
+// "3,5-8", the comma is read as the separator. A colon always takes precedence, so in a tail
+// such as "3:5,7" any comma belongs to the verse part instead. A tail of two chapters joined
+// by a dash, such as "1-3", is a chapter range.
 func parseTail(tail string) (string, error) {
+	for strings.Contains(tail, "::") {
+		tail = strings.ReplaceAll(tail, "::", ":")
+	}
+	if chapter, verses, found := strings.Cut(tail, ","); found && !strings.Contains(tail, ":") &&
+		isDigits(chapter) && startsWithDigit(verses) && !strings.Contains(verses, ",") {
+		tail = chapter + ":" + verses
+	}
+
 	if tail == "" {
 		return "", &BibleRefError{
 			Kind:    KindParse,
@@ -224,6 +805,10 @@ func parseTail(tail string) (string, error) {
 		return tail, nil
 	}
 
+	if rest := NormalizeVerseRange(tail[i:]); strings.HasPrefix(rest, util.EnDash) && isDigits(rest[len(util.EnDash):]) {
+		return tail[:i] + rest, nil
+	}
+
 	if tail[i] != ':' {
 		return "", &BibleRefError{
 			Kind:    KindParse,
@@ -246,18 +831,373 @@ func parseTail(tail string) (string, error) {
 	return tail[:i] + ":" + normalizedVerses, nil
 }
 
+// replaceCompactVerseSeparator replaces a lone 'v' between the chapter number and the verse
+// with a colon, turning "3v5-8" into "3:5-8". Any other tail is returned unchanged.
+func replaceCompactVerseSeparator(tail string) string {
+	i := 0
+	for i < len(tail) && tail[i] >= '0' && tail[i] <= '9' {
+		i++
+	}
+	if i == 0 || i+1 >= len(tail) || (tail[i] != 'v' && tail[i] != 'V') || !startsWithDigit(tail[i+1:]) {
+		return tail
+	}
+	return tail[:i] + ":" + tail[i+1:]
+}
+
+// intervalTail rewrites an interval such as "[3:5,3:8)" as the equivalent inclusive
+// chapter/verse tail, here "3:5-7". See WithIntervalNotation for the notation.
+func intervalTail(tail string) (string, error) {
+	invalid := &BibleRefError{
+		Kind:    KindParse,
+		Err:     ErrBibleRefParseFailed,
+		Message: util.Ptr(fmt.Sprintf("invalid interval: %s", tail)),
+	}
+
+	if len(tail) < 2 {
+		return "", invalid
+	}
+	closing := tail[len(tail)-1]
+	if closing != ']' && closing != ')' {
+		return "", invalid
+	}
+	startStr, endStr, found := strings.Cut(tail[1:len(tail)-1], ",")
+	if !found {
+		return "", invalid
+	}
+	startChapter, startVerse, ok := intervalEndpoint(startStr, 0)
+	if !ok {
+		return "", invalid
+	}
+	endChapter, endVerse, ok := intervalEndpoint(endStr, startChapter)
+	if !ok {
+		return "", invalid
+	}
+
+	if tail[0] == '(' {
+		startVerse++
+	}
+	if closing == ')' {
+		endVerse--
+	}
+	if endVerse < 1 || cmp.Or(cmp.Compare(startChapter, endChapter), cmp.Compare(startVerse, endVerse)) > 0 {
+		return "", &BibleRefError{
+			Kind:    KindInvalidVerse,
+			Err:     ErrInvalidVerse,
+			Message: util.Ptr(fmt.Sprintf("interval %s contains no verses", tail)),
+		}
+	}
+
+	switch {
+	case startChapter != endChapter:
+		return fmt.Sprintf("%d:%d-%d:%d", startChapter, startVerse, endChapter, endVerse), nil
+	case startVerse != endVerse:
+		return fmt.Sprintf("%d:%d-%d", startChapter, startVerse, endVerse), nil
+	default:
+		return fmt.Sprintf("%d:%d", startChapter, startVerse), nil
+	}
+}
+
+// intervalEndpoint parses an interval endpoint "chapter:verse", or a bare verse in
+// defaultChapter when defaultChapter is positive.
+func intervalEndpoint(s string, defaultChapter int) (chapter, verse int, ok bool) {
+	chapterStr, verseStr, found := strings.Cut(strings.TrimSpace(s), ":")
+	if !found {
+		chapterStr, verseStr = strconv.Itoa(defaultChapter), chapterStr
+	}
+	chapter, err := strconv.Atoi(chapterStr)
+	if err != nil || chapter < 1 || !isDigits(verseStr) {
+		return 0, 0, false
+	}
+	verse, err = strconv.Atoi(verseStr)
+	return chapter, verse, err == nil
+}
+
+// cutStep removes a "/step" suffix from the tail when stepped ranges are enabled,
+// returning the remaining tail and the step, or 0 when there is none.
+func cutStep(tail string, cfg parseConfig) (string, int, error) {
+	if !cfg.steppedRanges {
+		return tail, 0, nil
+	}
+	rest, stepStr, found := strings.Cut(tail, "/")
+	if !found {
+		return tail, 0, nil
+	}
+	step, err := strconv.Atoi(stepStr)
+	if err != nil || step < 1 {
+		return "", 0, &BibleRefError{
+			Kind:    KindParse,
+			Err:     ErrBibleRefParseFailed,
+			Message: util.Ptr(fmt.Sprintf("invalid range step: %s", stepStr)),
+			Cause:   err,
+		}
+	}
+	return rest, step, nil
+}
+
+// applyStep expands the verse range of ref into a verse list of every step-th verse,
+// starting at the start verse and not passing the end verse. An "ff" range has no end verse
+// yet and cannot be stepped.
+func applyStep(ref *BibleRef, step int) error {
+	if !ref.IsRange() || ref.EndChapter != nil || len(ref.Additional) > 0 {
+		return &BibleRefError{
+			Kind:    KindParse,
+			Err:     ErrBibleRefParseFailed,
+			Message: util.Ptr("a range step requires a single verse range within one chapter"),
+		}
+	}
+	if ref.Verse.IsOpenEnded() {
+		return &BibleRefError{
+			Kind:    KindParse,
+			Err:     ErrBibleRefParseFailed,
+			Message: util.Ptr(fmt.Sprintf("a range step requires an end verse, got %s", ref.Verse)),
+		}
+	}
+
+	start, end := ref.span()
+	ref.Verse = &util.VerseRange{StartVerse: start}
+	for v := start + step; v <= end; v += step {
+		ref.Additional = append(ref.Additional, util.VerseRange{StartVerse: v})
+	}
+	return nil
+}
+
+// joinVerseList rejoins a verse list that was split on the spaces after its commas, so
+// "5:3, 5, 7-9" becomes the single field "5:3,5,7-9".
+func joinVerseList(fields []string) []string {
+	var res []string
+	for _, f := range fields {
+		if n := len(res); n > 0 && strings.HasSuffix(res[n-1], ",") && startsWithDigit(f) {
+			res[n-1] += f
+			continue
+		}
+		res = append(res, f)
+	}
+	return res
+}
+
+// chapterVerseSeparators lists the separators that may be written with spaces around them
+// inside a chapter/verse tail: the colon and the range dashes accepted by NormalizeVerseRange.
+var chapterVerseSeparators = []string{":", util.Hyphen, util.EnDash, "\u2212", "\u2010"}
+
+// joinSpacedSeparators rejoins a chapter/verse tail that was split on spaces around a colon
+// or range dash, so "31 : 10 - 31" and "31: 10-31" both become the single field "31:10-31".
+// Only fields that follow a number are joined, so a book name is never merged into the tail.
+func joinSpacedSeparators(fields []string) []string {
+	var res []string
+	for _, f := range fields {
+		n := len(res)
+		if n > 0 && startsWithDigit(res[n-1]) {
+			prev := res[n-1]
+			endsWithSep := hasSeparatorSuffix(prev) && startsWithDigit(f)
+			startsWithSep := hasSeparatorPrefix(f) && prev[len(prev)-1] >= '0' && prev[len(prev)-1] <= '9'
+			if endsWithSep || startsWithSep {
+				res[n-1] += f
+				continue
+			}
+		}
+		res = append(res, f)
+	}
+	return res
+}
+
+func hasSeparatorPrefix(s string) bool {
+	for _, sep := range chapterVerseSeparators {
+		if strings.HasPrefix(s, sep) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasSeparatorSuffix(s string) bool {
+	for _, sep := range chapterVerseSeparators {
+		if strings.HasSuffix(s, sep) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitAttachedTail splits a chapter/verse tail that is written directly after the book
+// name without a space, e.g. "Psalm119:105" or "1Samuel3:1", into separate fields.
+// A leading numeric book prefix (the "1" in "1Samuel") is kept with the book name; the tail
+// starts at the first digit that follows a letter.
+func splitAttachedTail(fields []string) []string {
+	if len(fields) == 0 {
+		return fields
+	}
+
+	last := fields[len(fields)-1]
+	if startsWithDigit(last) && len(fields) > 1 {
+		return fields
+	}
+
+	i := 0
+	for i < len(last) && last[i] >= '0' && last[i] <= '9' {
+		i++
+	}
+	seenLetter := false
+	for ; i < len(last); i++ {
+		c := rune(last[i])
+		if c >= '0' && c <= '9' {
+			if !seenLetter {
+				return fields
+			}
+			res := append([]string{}, fields[:len(fields)-1]...)
+			return append(res, last[:i], last[i:])
+		}
+		if unicode.IsLetter(c) {
+			seenLetter = true
+		}
+	}
+
+	return fields
+}
+
+// checkStrayBook rejects references that contain a second book token after the chapter,
+// e.g. "Prov 3:5 Matt 1" or "Prov 31:10-Matt 1". Only a leading numeric prefix such as the
+// "1" in "1 Samuel" may start with a digit before the chapter/verse tail. A token is only
+// taken for a book when it starts with a letter; "Prov 3 5" is reported as a missing
+// chapter-verse separator instead.
+func checkStrayBook(fields []string) error {
+	for i := 1; i < len(fields)-1; i++ {
+		if !startsWithDigit(fields[i]) {
+			continue
+		}
+		if startsWithLetter(fields[i+1]) {
+			return strayBookError(fields[i], fields[i+1])
+		}
+		if err := checkDashedBook(fields[i]); err != nil {
+			return err
+		}
+		return &BibleRefError{
+			Kind:    KindParse,
+			Err:     ErrBibleRefParseFailed,
+			Message: util.Ptr(fmt.Sprintf("missing chapter-verse separator between %q and %q", fields[i], fields[i+1])),
+		}
+	}
+
+	return checkDashedBook(fields[len(fields)-1])
+}
+
+// checkDashedBook rejects a chapter/verse token whose range runs into a book name, such as
+// "31:10-Matt".
+func checkDashedBook(tok string) error {
+	for i := 0; i < len(tok)-1; i++ {
+		if tok[i] != '-' && !strings.HasPrefix(tok[i:], util.EnDash) {
+			continue
+		}
+		rest := strings.TrimPrefix(strings.TrimPrefix(tok[i:], "-"), util.EnDash)
+		if rest != "" && unicode.IsLetter(rune(rest[0])) {
+			return strayBookError(tok[:i], rest)
+		}
+	}
+	return nil
+}
+
+func strayBookError(after, book string) error {
+	return &BibleRefError{
+		Kind:    KindParse,
+		Err:     ErrUnexpectedBookToken,
+		Message: util.Ptr(fmt.Sprintf("unexpected book token %q after chapter/verse %q", book, after)),
+	}
+}
+
+// chapterQualifiers lists the words that may precede a chapter number, e.g. "Prov ch. 3" or
+// the older "Prov Chap. 3". A trailing period is removed before the lookup.
+var chapterQualifiers = map[string]bool{
+	"ch":       true,
+	"chap":     true,
+	"chaps":    true,
+	"chapter":  true,
+	"chapters": true,
+}
+
+// verseQualifiers lists the words that may separate a chapter number from its verses.
+// The singular forms introduce a single verse ("Prov 3 v. 5") and the plural forms
+// a range ("Prov 3 vv. 5-8"); both resolve to the same chapter:verse structure. The "vs." and
+// "vss." abbreviations of older commentaries are accepted in the same way.
+var verseQualifiers = map[string]bool{
+	"v":      true,
+	"vv":     true,
+	"vs":     true,
+	"vss":    true,
+	"verse":  true,
+	"verses": true,
+}
+
+// applyQualifiers rewrites chapter and verse qualifier words into the compact
+// "chapter:verse" form understood by parseTail. A chapter qualifier is dropped when it
+// is followed by a number, unless it follows a bare ordinal such as the "1" or "I" of
+// "1 Ch 3:5", where "Ch" abbreviates Chronicles. A verse qualifier is folded into the preceding chapter
+// token when it sits between two numbers. Any other word is left untouched so book
+// names are never rewritten. A final "f" or "ff" after a verse, as in "Rom 8:28 ff", is
+// attached to it.
+func applyQualifiers(fields []string) []string {
+	res := make([]string, 0, len(fields))
+	for i := 0; i < len(fields); i++ {
+		word := strings.TrimSuffix(strings.ToLower(fields[i]), ".")
+		hasNext := i+1 < len(fields) && startsWithDigit(fields[i+1])
+
+		if chapterQualifiers[word] && hasNext && len(res) > 0 && !isOrdinalPrefix(res[len(res)-1]) {
+			continue
+		}
+		if verseQualifiers[word] && hasNext && len(res) > 0 && isDigits(res[len(res)-1]) {
+			res[len(res)-1] += ":" + fields[i+1]
+			i++
+			continue
+		}
+		if (word == util.FollowingVerse || word == util.FollowingVerses) && i == len(fields)-1 && len(res) > 1 && endsWithDigit(res[len(res)-1]) {
+			res[len(res)-1] += word
+			continue
+		}
+		res = append(res, fields[i])
+	}
+	return res
+}
+
+// isOrdinalPrefix reports whether word is a bare numeral that may open a numbered book name,
+// such as "1", "II", or a prefix added with RegisterOrdinalPrefix.
+func isOrdinalPrefix(word string) bool {
+	if isDigits(word) {
+		return true
+	}
+	word = strings.ToLower(word)
+	ordinalPrefixesMu.RLock()
+	defer ordinalPrefixesMu.RUnlock()
+	return slices.ContainsFunc(ordinalPrefixes, func(p ordinalPrefix) bool { return p.prefix == word })
+}
+
+func endsWithDigit(s string) bool {
+	return s != "" && s[len(s)-1] >= '0' && s[len(s)-1] <= '9'
+}
+
+func startsWithDigit(s string) bool {
+	return s != "" && s[0] >= '0' && s[0] <= '9'
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 // NormalizeAlias normalizes a book name or alias by trimming whitespace, converting to lowercase,
-// removing punctuation, and replacing hyphens with en dashes. It also handles common roman numeral prefixes.
+// removing punctuation, and replacing hyphens with en dashes. It also handles common roman numeral prefixes;
+// see normalizeRomanPrefix.
 func NormalizeAlias(s string) string {
 	res := strings.TrimSpace(s)
 	res = strings.ToLower(res)
 	res = strings.ReplaceAll(res, ".", "")
 	res = strings.ReplaceAll(res, util.EnDash, util.Hyphen)
 
-	// handle roman numeral prefixes
-	res = strings.ReplaceAll(res, "iii ", "3 ")
-	res = strings.ReplaceAll(res, "ii ", "2 ")
-	res = strings.ReplaceAll(res, "i ", "1 ")
+	res = normalizeRomanPrefix(res)
 
 	// unicode apostrophes & quotation marks
 	res = strings.ReplaceAll(res, "’", "'")
@@ -268,11 +1208,122 @@ func NormalizeAlias(s string) string {
 	return res
 }
 
+// ordinalPrefix maps a roman numeral or ordinal word that may open a numbered book name to
+// its digits.
+type ordinalPrefix struct {
+	prefix, digit string
+}
+
+// defaultOrdinalPrefixes are the numerals of the numbered books of the Bible, longest first.
+var defaultOrdinalPrefixes = []ordinalPrefix{
+	{"iii", "3"},
+	{"ii", "2"},
+	{"i", "1"},
+}
+
+// ordinalPrefixes holds the prefixes normalizeRomanPrefix reads, longest first so that "iii"
+// is tried before "ii" and "i". It is guarded by ordinalPrefixesMu.
+var (
+	ordinalPrefixesMu sync.RWMutex
+	ordinalPrefixes   = slices.Clone(defaultOrdinalPrefixes)
+)
+
+// RegisterOrdinalPrefix adds a roman numeral or ordinal word that NormalizeAlias replaces with
+// digit at the start of a book name, e.g. "iv" for "4" so that "IV Maccabees" normalizes to
+// "4 maccabees". The prefix is matched case-insensitively and only when followed by a space;
+// registering an existing prefix replaces its digit. Tables normalize their aliases when they
+// are built, so register prefixes before calling NewTable. It returns a BibleRefError if the
+// prefix is not made of letters or digit is not made of ASCII digits.
+func RegisterOrdinalPrefix(prefix, digit string) error {
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+	if prefix == "" || strings.IndexFunc(prefix, func(r rune) bool { return !unicode.IsLetter(r) }) >= 0 || !isDigits(digit) {
+		return &BibleRefError{
+			Kind:    KindInvalidBook,
+			Err:     ErrInvalidBook,
+			Message: util.Ptr(fmt.Sprintf("invalid ordinal prefix %q for %q", prefix, digit)),
+		}
+	}
+
+	ordinalPrefixesMu.Lock()
+	defer ordinalPrefixesMu.Unlock()
+	ordinalPrefixes = slices.DeleteFunc(ordinalPrefixes, func(p ordinalPrefix) bool { return p.prefix == prefix })
+	ordinalPrefixes = append(ordinalPrefixes, ordinalPrefix{prefix: prefix, digit: digit})
+	slices.SortStableFunc(ordinalPrefixes, func(a, b ordinalPrefix) int {
+		return cmp.Compare(len(b.prefix), len(a.prefix))
+	})
+	return nil
+}
+
+// ResetOrdinalPrefixes restores the default ordinal prefixes "i", "ii", and "iii", removing
+// any registered with RegisterOrdinalPrefix.
+func ResetOrdinalPrefixes() {
+	ordinalPrefixesMu.Lock()
+	defer ordinalPrefixesMu.Unlock()
+	ordinalPrefixes = slices.Clone(defaultOrdinalPrefixes)
+}
+
+// numberedBookStems lists the starts of the names and abbreviations of numbered books. A lone
+// "i" joined to a name is only read as a numeral before one of these, so "isam" is 1 Samuel
+// while "isaiah" and "isa" are left alone.
+var numberedBookStems = []string{
+	"sam", "sm", "ki", "kg", "chr", "ch", "cor", "co", "th", "tim", "ti",
+	"pet", "pe", "pt", "jo", "jn", "jhn", "esd", "mac",
+}
+
+// normalizeRomanPrefix replaces a roman numeral at the start of a lowercase book name with its
+// digit, keeping any space that follows: "i john" becomes "1 john" and "iijohn" becomes
+// "2john". The numeral must be followed by a space, or by a letter for the default "ii" and
+// "iii" and for a lone "i" before one of numberedBookStems. Prefixes added with
+// RegisterOrdinalPrefix need the space. Numerals elsewhere in the name are left alone.
+func normalizeRomanPrefix(s string) string {
+	ordinalPrefixesMu.RLock()
+	defer ordinalPrefixesMu.RUnlock()
+	for _, p := range ordinalPrefixes {
+		rest, ok := strings.CutPrefix(s, p.prefix)
+		if !ok {
+			continue
+		}
+		if strings.HasPrefix(rest, " ") || startsWithLetter(rest) && joinedPrefixAllowed(p.prefix, rest) {
+			return p.digit + rest
+		}
+		return s
+	}
+	return s
+}
+
+// joinedPrefixAllowed reports whether the numeral prefix may be read when written directly
+// before rest, as in "iijohn".
+func joinedPrefixAllowed(prefix, rest string) bool {
+	switch prefix {
+	case "ii", "iii":
+		return true
+	case "i":
+		return hasNumberedBookStem(rest)
+	}
+	return false
+}
+
+// hasNumberedBookStem reports whether s starts with one of numberedBookStems.
+func hasNumberedBookStem(s string) bool {
+	return slices.ContainsFunc(numberedBookStems, func(stem string) bool {
+		return strings.HasPrefix(s, stem)
+	})
+}
+
+// startsWithLetter reports whether s begins with a letter.
+func startsWithLetter(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return unicode.IsLetter(r)
+}
+
 // NormalizeVerseRange normalizes a verse range string by trimming whitespace,
-// replacing hyphens with en dashes, and removing spaces.
+// replacing hyphens with en dashes, and removing spaces. The minus sign (U+2212) and the
+// Unicode hyphen (U+2010) look like hyphens and are treated the same way.
 func NormalizeVerseRange(s string) string {
 	res := strings.TrimSpace(s)
 	res = strings.ReplaceAll(res, util.Hyphen, util.EnDash)
+	res = strings.ReplaceAll(res, "\u2212", util.EnDash)
+	res = strings.ReplaceAll(res, "\u2010", util.EnDash)
 	res = strings.ReplaceAll(res, " ", "")
 	return res
 }
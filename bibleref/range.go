@@ -0,0 +1,290 @@
+package bibleref
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/julianstephens/canonref/util"
+)
+
+// PassageRange is a continuous passage that runs from Start through End, possibly spanning
+// several books, e.g. "Gen 1" through "Exod 3".
+type PassageRange struct {
+	Start BibleRef
+	End   BibleRef
+}
+
+// ChapterCount returns the number of chapters the PassageRange touches, counting the chapters
+// of Start and End in full. Books between the two are counted by their chapter totals, following
+// the Order of the books in the Table. It returns an error if either book is unknown or if End
+// comes before Start.
+func (pr PassageRange) ChapterCount(tbl *Table) (int, error) {
+	startBook, err := pr.Start.book(tbl)
+	if err != nil {
+		return 0, err
+	}
+	endBook, err := pr.End.book(tbl)
+	if err != nil {
+		return 0, err
+	}
+
+	endChapter := pr.End.endChapter()
+	if endBook.Order < startBook.Order || (startBook.OSIS == endBook.OSIS && endChapter < pr.Start.Chapter) {
+		return 0, &BibleRefError{
+			Kind:    KindInvalidChapter,
+			Err:     ErrInvalidChapter,
+			Message: util.Ptr(fmt.Sprintf("passage range ends before it starts: %s to %s", pr.Start, pr.End)),
+		}
+	}
+
+	if startBook.OSIS == endBook.OSIS {
+		return endChapter - pr.Start.Chapter + 1, nil
+	}
+
+	total := startBook.Chapters - pr.Start.Chapter + 1 + endChapter
+	for order := startBook.Order + 1; order < endBook.Order; order++ {
+		if book, ok := tbl.BookByOrder(order); ok {
+			total += book.Chapters
+		}
+	}
+	return total, nil
+}
+
+// InBookRange reports whether the BibleRef's book lies between the books startOSIS and endOSIS,
+// inclusive, in the canonical Order of the Table, e.g. within the Pentateuch for "Gen" and
+// "Deut". It returns an error if any of the books is unknown or if the end book comes before
+// the start book.
+func (r BibleRef) InBookRange(startOSIS, endOSIS string, tbl *Table) (bool, error) {
+	book, err := r.book(tbl)
+	if err != nil {
+		return false, err
+	}
+	start, err := BibleRef{OSIS: startOSIS}.book(tbl)
+	if err != nil {
+		return false, err
+	}
+	end, err := BibleRef{OSIS: endOSIS}.book(tbl)
+	if err != nil {
+		return false, err
+	}
+
+	if end.Order < start.Order {
+		return false, &BibleRefError{
+			Kind:    KindInvalidBook,
+			Err:     ErrInvalidBook,
+			Message: util.Ptr(fmt.Sprintf("book range ends before it starts: %s to %s", startOSIS, endOSIS)),
+		}
+	}
+
+	return book.Order >= start.Order && book.Order <= end.Order, nil
+}
+
+// Contains reports whether every verse of other is also cited by r, e.g. whether
+// "Prov 31:15" falls within "Prov 31:10–31". A single verse is treated as a range that starts
+// and ends on that verse, so a reference contains itself. A chapter-only reference spans every
+// verse of its chapter. For verse lists, each segment of other must lie within a single
+// segment of r. It returns false when the OSIS codes differ.
+func (r BibleRef) Contains(other BibleRef) bool {
+	if r.OSIS != other.OSIS {
+		return false
+	}
+	outer := r.segments()
+	for _, seg := range other.segments() {
+		if !slices.ContainsFunc(outer, func(o segment) bool {
+			return o.start <= seg.start && seg.end <= o.end
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// Overlaps reports whether r and other cite at least one verse in common. Single verses and
+// chapter-only references are treated as in Contains. It returns false when the OSIS codes differ.
+func (r BibleRef) Overlaps(other BibleRef) bool {
+	if r.OSIS != other.OSIS {
+		return false
+	}
+	for _, a := range r.segments() {
+		for _, b := range other.segments() {
+			if a.start <= b.end && b.start <= a.end {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// verseLimit bounds the verse component of a position, standing in for "the last verse" of a
+// chapter-only reference, which has no verse count to hand.
+const verseLimit = 1 << 16
+
+// segment is an inclusive span of positions, where a position encodes a chapter and verse as
+// chapter*verseLimit + verse so that positions compare in reading order across chapters.
+type segment struct {
+	start, end int
+}
+
+// segments returns the spans of verses the BibleRef cites, in the order cited.
+func (r BibleRef) segments() []segment {
+	endChapter := r.endChapter()
+	if r.Verse == nil {
+		return []segment{{start: r.Chapter*verseLimit + 1, end: endChapter*verseLimit + verseLimit - 1}}
+	}
+
+	start, end := r.span()
+	segs := []segment{{start: r.Chapter*verseLimit + start, end: endChapter*verseLimit + end}}
+	for _, v := range r.Additional {
+		start, end := segmentSpan(v)
+		segs = append(segs, segment{start: r.Chapter*verseLimit + start, end: r.Chapter*verseLimit + end})
+	}
+	return segs
+}
+
+// Subtract removes the verses of other from r and returns the remaining segments in order.
+// For example, "Prov 3:1–10" minus "Prov 3:4–5" yields "Prov 3:1–3" and "Prov 3:6–10".
+// It returns false if the references do not overlap, including when they are in different
+// books or chapters. A chapter-only other removes all of r, leaving no segments. A chapter-only
+// r cannot be split without verse counts, so Subtract reports false unless other is also
+// chapter-only. Cross-chapter ranges and verse lists are not supported and always report false.
+func (r BibleRef) Subtract(other BibleRef) ([]BibleRef, bool) {
+	if r.EndChapter != nil || other.EndChapter != nil || len(r.Additional) > 0 || len(other.Additional) > 0 {
+		return nil, false
+	}
+	if r.OSIS != other.OSIS || r.Chapter != other.Chapter {
+		return nil, false
+	}
+	if other.Verse == nil {
+		return []BibleRef{}, true
+	}
+	if r.Verse == nil {
+		return nil, false
+	}
+
+	start, end := r.span()
+	otherStart, otherEnd := other.span()
+	if otherEnd < start || otherStart > end {
+		return nil, false
+	}
+
+	res := []BibleRef{}
+	if start < otherStart {
+		res = append(res, verseSpanRef(r.OSIS, r.Chapter, start, otherStart-1))
+	}
+	if otherEnd < end {
+		res = append(res, verseSpanRef(r.OSIS, r.Chapter, otherEnd+1, end))
+	}
+	return res, true
+}
+
+// span returns the inclusive start and end verses of the first verse segment of a BibleRef
+// that has a Verse.
+func (r BibleRef) span() (int, int) {
+	return segmentSpan(*r.Verse)
+}
+
+// verseSpanRef builds a BibleRef covering start through end, using a single verse when they are
+// equal and the "ff" notation when end is the unknown last verse of the chapter.
+func verseSpanRef(osis string, chapter, start, end int) BibleRef {
+	verse := &util.VerseRange{StartVerse: start}
+	if end == unknownChapterSize {
+		verse.Following = util.FollowingVerses
+	} else if end != start {
+		verse.EndVerse = util.Ptr(end)
+	}
+	return BibleRef{OSIS: osis, Chapter: chapter, Verse: verse}
+}
+
+// ClampToBounds returns a copy of r truncated to the bounds of its book, and whether anything
+// was changed. Chapters are clamped to the book's chapter count, and when the book has
+// VerseCounts, verses are clamped to the verse count of their chapter, so "Prov 31:10–40"
+// becomes "Prov 31:10–31". A range whose end chapter is past the end of the book runs to the
+// last verse of the book, so "Ruth 3:10–6:5" becomes "Ruth 3:10–4:22"; without a verse count
+// for the last chapter, a range left within one chapter ends with the "ff" notation, as in
+// "Gen 50:5ff". An "ff" range whose last verse is unknown is ended at the last verse of its
+// chapter when the book has a verse count for it, without counting as a change. Other verses
+// of a chapter without a verse count are left unchanged.
+// Segments of a verse list that start past the end of the chapter are dropped. A range that
+// collapses to one chapter or one verse is simplified accordingly. It returns an error if the
+// book is not in the Table.
+func (r BibleRef) ClampToBounds(tbl *Table) (BibleRef, bool, error) {
+	book, err := r.book(tbl)
+	if err != nil {
+		return r, false, err
+	}
+
+	clamped := false
+	clamp := func(v, lo, hi int) int {
+		c := min(max(v, lo), hi)
+		clamped = clamped || c != v
+		return c
+	}
+
+	res := BibleRef{OSIS: r.OSIS, Chapter: clamp(r.Chapter, 1, book.Chapters)}
+	if r.EndChapter != nil {
+		if end := clamp(*r.EndChapter, res.Chapter, book.Chapters); end != res.Chapter {
+			res.EndChapter = &end
+		}
+	}
+	if r.Verse == nil {
+		return res, clamped, nil
+	}
+
+	maxVerse := func(chapter int) int {
+		if count, ok := book.VersesIn(chapter); ok {
+			return count
+		}
+		return math.MaxInt
+	}
+
+	start, end := r.span()
+	start = clamp(start, book.firstVerse(), maxVerse(res.Chapter))
+	if r.EndChapter != nil && *r.EndChapter > book.Chapters {
+		count, ok := book.VersesIn(res.endChapter())
+		switch {
+		case ok:
+			end = count
+		case res.EndChapter == nil:
+			end = unknownChapterSize
+		}
+	}
+	if end == unknownChapterSize && res.EndChapter == nil {
+		res.Verse = util.Ptr(followingSegment(book, res.Chapter, start))
+	} else {
+		end = clamp(end, util.If(res.EndChapter == nil, start, book.firstVerse()), maxVerse(res.endChapter()))
+		res.Verse = &util.VerseRange{StartVerse: start}
+		if end != start || res.EndChapter != nil {
+			res.Verse.EndVerse = util.Ptr(end)
+		}
+	}
+
+	limit := maxVerse(res.Chapter)
+	for _, v := range r.Additional {
+		start, end := segmentSpan(v)
+		if start > limit {
+			clamped = true
+			continue
+		}
+		if v.IsOpenEnded() {
+			res.Additional = append(res.Additional, followingSegment(book, res.Chapter, start))
+			continue
+		}
+		seg := util.VerseRange{StartVerse: start}
+		if end = clamp(end, start, limit); end != start {
+			seg.EndVerse = util.Ptr(end)
+		}
+		res.Additional = append(res.Additional, seg)
+	}
+	return res, clamped, nil
+}
+
+// followingSegment returns the "ff" range from start to the end of the chapter, ending it at
+// the chapter's last verse when the book has a verse count for it, as Parse does.
+func followingSegment(book Book, chapter, start int) util.VerseRange {
+	v := util.VerseRange{StartVerse: start, Following: util.FollowingVerses}
+	if count, ok := book.VersesIn(chapter); ok && count > start {
+		v.EndVerse = util.Ptr(count)
+	}
+	return v
+}
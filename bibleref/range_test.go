@@ -0,0 +1,262 @@
+package bibleref_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/julianstephens/canonref/bibleref"
+	"github.com/julianstephens/canonref/util"
+)
+
+// joinRefs renders references in canonical form separated by "; " for compact assertions.
+func joinRefs(refs []bibleref.BibleRef) string {
+	parts := make([]string, len(refs))
+	for i, ref := range refs {
+		parts[i] = ref.String()
+	}
+	return strings.Join(parts, "; ")
+}
+
+// TestBibleRef_Subtract tests removing part of a verse range from the middle, start, and end.
+func TestBibleRef_Subtract(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		from        string
+		minus       string
+		expected    string
+		expectedOk  bool
+		description string
+	}{
+		{"Prov 3:1-10", "Prov 3:4-5", "Prov 3:1–3; Prov 3:6–10", true, "middle"},
+		{"Prov 3:1-10", "Prov 3:1-3", "Prov 3:4–10", true, "start"},
+		{"Prov 3:1-10", "Prov 3:9-12", "Prov 3:1–8", true, "end overlapping past the range"},
+		{"Prov 3:1-10", "Prov 3:2", "Prov 3:1; Prov 3:3–10", true, "single verse"},
+		{"Prov 3:1-10", "Prov 3", "", true, "whole chapter"},
+		{"Prov 3:1-10", "Prov 3:11-12", "", false, "disjoint"},
+		{"Prov 3:1-10", "Prov 4:1-2", "", false, "different chapter"},
+		{"Prov 3:5ff", "Prov 3:8", "Prov 3:5–7; Prov 3:9ff", true, "ff runs to the end of the chapter"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			got, ok := bibleref.MustParse(tc.from, tbl).Subtract(*bibleref.MustParse(tc.minus, tbl))
+			if ok != tc.expectedOk {
+				t.Fatalf("expected ok %v, got %v", tc.expectedOk, ok)
+			}
+			if joinRefs(got) != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, joinRefs(got))
+			}
+		})
+	}
+}
+
+// TestPassageRange_ChapterCount tests counting chapters within one book and across books,
+// including books listed between the two endpoints.
+func TestPassageRange_ChapterCount(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		start    string
+		end      string
+		expected int
+		desc     string
+	}{
+		{"Gen 1", "Gen 3", 3, "intra-book span"},
+		{"Prov 3:5", "Prov 3:8", 1, "single chapter"},
+		{"Gen 50", "1Sam 2", 3, "adjacent books in order"},
+		{"Gen 1", "Ps 3", 108, "cross-book span with books between"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			pr := bibleref.PassageRange{
+				Start: *bibleref.MustParse(tc.start, tbl),
+				End:   *bibleref.MustParse(tc.end, tbl),
+			}
+			got, err := pr.ChapterCount(tbl)
+			if err != nil {
+				t.Fatalf("ChapterCount failed: %v", err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected %d chapters, got %d", tc.expected, got)
+			}
+		})
+	}
+
+	t.Run("end before start", func(t *testing.T) {
+		pr := bibleref.PassageRange{
+			Start: *bibleref.MustParse("Ps 3", tbl),
+			End:   *bibleref.MustParse("Gen 1", tbl),
+		}
+		if _, err := pr.ChapterCount(tbl); !errors.Is(err, bibleref.ErrInvalidChapter) {
+			t.Errorf("expected ErrInvalidChapter, got %v", err)
+		}
+	})
+}
+
+// TestBibleRef_InBookRange tests references inside, outside, and at the boundaries of the
+// Pentateuch.
+func TestBibleRef_InBookRange(t *testing.T) {
+	tbl, err := bibleref.NewTable([]bibleref.Book{
+		{OSIS: "Gen", Name: "Genesis", Testament: "OT", Order: 1, Chapters: 50},
+		{OSIS: "Exod", Name: "Exodus", Testament: "OT", Order: 2, Chapters: 40},
+		{OSIS: "Lev", Name: "Leviticus", Testament: "OT", Order: 3, Chapters: 27},
+		{OSIS: "Num", Name: "Numbers", Testament: "OT", Order: 4, Chapters: 36},
+		{OSIS: "Deut", Name: "Deuteronomy", Testament: "OT", Order: 5, Chapters: 34},
+		{OSIS: "Josh", Name: "Joshua", Testament: "OT", Order: 6, Chapters: 24},
+		{OSIS: "Matt", Name: "Matthew", Testament: "NT", Order: 40, Chapters: 28},
+	})
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected bool
+		desc     string
+	}{
+		{"Lev 19:18", true, "inside"},
+		{"Gen 1:1", true, "start boundary"},
+		{"Deut 34:12", true, "end boundary"},
+		{"Josh 1:1", false, "just after"},
+		{"Matt 5:3", false, "outside"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := bibleref.MustParse(tc.input, tbl).InBookRange("Gen", "Deut", tbl)
+			if err != nil {
+				t.Fatalf("InBookRange failed: %v", err)
+			}
+			if got != tc.expected {
+				t.Errorf("InBookRange(%q) = %v, expected %v", tc.input, got, tc.expected)
+			}
+		})
+	}
+
+	ref := bibleref.MustParse("Lev 1", tbl)
+	if _, err := ref.InBookRange("Gen", "Nope", tbl); !errors.Is(err, bibleref.ErrInvalidOSISCode) {
+		t.Errorf("expected ErrInvalidOSISCode for unknown end book, got %v", err)
+	}
+	if _, err := ref.InBookRange("Deut", "Gen", tbl); !errors.Is(err, bibleref.ErrInvalidBook) {
+		t.Errorf("expected ErrInvalidBook for reversed range, got %v", err)
+	}
+}
+
+// TestBibleRef_ContainsOverlaps tests passage containment and overlap, including single verses,
+// chapter-only references, and different books.
+func TestBibleRef_ContainsOverlaps(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		r, other           string
+		contains, overlaps bool
+		desc               string
+	}{
+		{"Prov 31:10-31", "Prov 31:15", true, true, "verse inside range"},
+		{"Prov 31:10-31", "Prov 31:10-31", true, true, "identical ranges"},
+		{"Prov 31:15", "Prov 31:15", true, true, "single verse contains itself"},
+		{"Prov 31:15", "Prov 31:10-31", false, true, "verse does not contain range"},
+		{"Prov 31:10-20", "Prov 31:15-25", false, true, "partial overlap"},
+		{"Prov 31:10-20", "Prov 31:21-25", false, false, "adjacent ranges"},
+		{"Prov 31", "Prov 31:30", true, true, "chapter contains verse"},
+		{"Prov 31:30", "Prov 31", false, true, "verse within chapter"},
+		{"Prov 30", "Prov 31:1", false, false, "different chapters"},
+		{"Prov 3:10", "Matt 3:10", false, false, "different books"},
+		{"Gen 1:30-2:3", "Gen 2:1", true, true, "cross-chapter range contains verse"},
+		{"Gen 1:30-2:3", "Gen 2:4", false, false, "after cross-chapter range"},
+		{"Matt 5:3,5,7-9", "Matt 5:8", true, true, "verse list contains verse"},
+		{"Matt 5:3,5,7-9", "Matt 5:4", false, false, "gap in verse list"},
+		{"Matt 5:1-10", "Matt 5:3,5,7-9", true, true, "range contains verse list"},
+		{"Prov 8:28ff", "Prov 8:29", true, true, "ff contains a later verse"},
+		{"Prov 8:28ff", "Prov 8:27", false, false, "ff starts at its verse"},
+		{"Prov 8", "Prov 8:28ff", true, true, "chapter contains ff"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			r, other := bibleref.MustParse(tc.r, tbl), bibleref.MustParse(tc.other, tbl)
+			if got := r.Contains(*other); got != tc.contains {
+				t.Errorf("%q.Contains(%q) = %v, expected %v", tc.r, tc.other, got, tc.contains)
+			}
+			if got := r.Overlaps(*other); got != tc.overlaps {
+				t.Errorf("%q.Overlaps(%q) = %v, expected %v", tc.r, tc.other, got, tc.overlaps)
+			}
+			if got := other.Overlaps(*r); got != tc.overlaps {
+				t.Errorf("%q.Overlaps(%q) = %v, expected %v", tc.other, tc.r, got, tc.overlaps)
+			}
+		})
+	}
+}
+
+// TestBibleRef_ClampToBounds tests truncating references to the chapters and verse counts of
+// their book.
+func TestBibleRef_ClampToBounds(t *testing.T) {
+	tbl, err := bibleref.NewTable([]bibleref.Book{
+		{OSIS: "Ruth", Name: "Ruth", Testament: "OT", Order: 8, Chapters: 4, VerseCounts: []int{22, 23, 18, 22}},
+		{OSIS: "Gen", Name: "Genesis", Testament: "OT", Order: 1, Chapters: 50},
+		{OSIS: "Ps", Name: "Psalms", Testament: "OT", Order: 19, Chapters: 150, VerseCounts: slices.Repeat([]int{19}, 150), AllowVerseZero: true},
+	})
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+	ref := func(chapter int, endChapter *int, start int, end *int, additional ...util.VerseRange) bibleref.BibleRef {
+		return bibleref.BibleRef{OSIS: "Ruth", Chapter: chapter, EndChapter: endChapter, Verse: &util.VerseRange{StartVerse: start, EndVerse: end}, Additional: additional}
+	}
+
+	testCases := []struct {
+		input    bibleref.BibleRef
+		expected string
+		clamped  bool
+		desc     string
+	}{
+		{ref(4, nil, 10, util.Ptr(40)), "Ruth 4:10–22", true, "end verse over max"},
+		{ref(2, nil, 30, nil), "Ruth 2:23", true, "single verse over max"},
+		{ref(7, nil, 1, nil), "Ruth 4:1", true, "chapter over max"},
+		{ref(3, util.Ptr(6), 10, util.Ptr(5)), "Ruth 3:10–4:22", true, "end chapter over max"},
+		{ref(4, util.Ptr(6), 5, util.Ptr(2)), "Ruth 4:5–22", true, "over-max chapter with a verse"},
+		{ref(7, util.Ptr(9), 5, util.Ptr(2)), "Ruth 4:5–22", true, "over-max start and end chapters"},
+		{bibleref.BibleRef{OSIS: "Gen", Chapter: 50, EndChapter: util.Ptr(55), Verse: &util.VerseRange{StartVerse: 5, EndVerse: util.Ptr(2)}}, "Gen 50:5ff", true, "over-max chapter without verse counts"},
+		{ref(1, nil, 20, nil, util.VerseRange{StartVerse: 21, EndVerse: util.Ptr(30)}, util.VerseRange{StartVerse: 25}), "Ruth 1:20,21–22", true, "verse list"},
+		{bibleref.BibleRef{OSIS: "Ruth", Chapter: 2, EndChapter: util.Ptr(9)}, "Ruth 2–4", true, "chapter range"},
+		{ref(3, nil, 5, util.Ptr(8)), "Ruth 3:5–8", false, "within bounds"},
+		{bibleref.BibleRef{OSIS: "Gen", Chapter: 60, Verse: &util.VerseRange{StartVerse: 99}}, "Gen 50:99", true, "no verse counts"},
+		{bibleref.BibleRef{OSIS: "Ps", Chapter: 51, Verse: &util.VerseRange{StartVerse: 0, EndVerse: util.Ptr(2)}}, "Ps 51:0–2", false, "verse zero allowed"},
+		{bibleref.BibleRef{OSIS: "Ps", Chapter: 51, EndChapter: util.Ptr(52), Verse: &util.VerseRange{StartVerse: 18, EndVerse: util.Ptr(0)}}, "Ps 51:18–52:0", false, "cross-chapter range ending at verse zero"},
+		{ref(1, nil, 0, nil), "Ruth 1:1", true, "verse zero not allowed"},
+		{bibleref.BibleRef{OSIS: "Ruth", Chapter: 1, Verse: &util.VerseRange{StartVerse: 20, Following: util.FollowingVerses}}, "Ruth 1:20ff", false, "ff filled from verse counts"},
+		{bibleref.BibleRef{OSIS: "Gen", Chapter: 2, Verse: &util.VerseRange{StartVerse: 4, Following: util.FollowingVerses}}, "Gen 2:4ff", false, "ff without verse counts"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			before := tc.input.String()
+			got, clamped, err := tc.input.ClampToBounds(tbl)
+			if err != nil {
+				t.Fatalf("ClampToBounds failed: %v", err)
+			}
+			if got.String() != tc.expected || clamped != tc.clamped {
+				t.Errorf("expected %q (clamped: %v), got %q (clamped: %v)", tc.expected, tc.clamped, got.String(), clamped)
+			}
+			if tc.input.String() != before {
+				t.Errorf("expected input to be unchanged, got %q", tc.input.String())
+			}
+		})
+	}
+
+	if _, _, err := (bibleref.BibleRef{OSIS: "Nope", Chapter: 1}).ClampToBounds(tbl); !errors.Is(err, bibleref.ErrInvalidOSISCode) {
+		t.Errorf("expected ErrInvalidOSISCode, got %v", err)
+	}
+}
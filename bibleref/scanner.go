@@ -0,0 +1,116 @@
+package bibleref
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"slices"
+	"unicode"
+)
+
+// Scanner extracts references from a stream of text one at a time, in the manner of
+// bufio.Scanner. It reads the input a line at a time, so a reference split across reads is
+// found as long as it does not span a line break. A line longer than the read buffer is
+// taken in bounded chunks, holding back the end of each chunk for the next so that a
+// reference crossing a chunk boundary is still found whole; memory use is therefore bounded
+// even for untrusted input without line breaks. Spans that look like references but fail to
+// parse are skipped; see ParseMany.
+//
+//	sc := NewScanner(file, tbl)
+//	for sc.Scan() {
+//		fmt.Println(sc.Ref())
+//	}
+//	if err := sc.Err(); err != nil {
+//		// handle the read error
+//	}
+type Scanner struct {
+	r       *bufio.Reader
+	tbl     *Table
+	carry   string
+	pending []*BibleRef
+	ref     *BibleRef
+	err     error
+	done    bool
+}
+
+const (
+	// scanChunkSize is the size of the Scanner's read buffer, the most it reads of a line at once.
+	scanChunkSize = 64 << 10
+	// scanOverlap is how much of the end of a chunk is held back for the next chunk. It is
+	// the longest input Parse accepts, so any reference that starts before it fits in the chunk.
+	scanOverlap = DefaultMaxInputLength
+	// scanWordLimit bounds how far the held back text is extended to start at a word.
+	scanWordLimit = 64
+)
+
+// NewScanner returns a Scanner that reads from r and resolves books against tbl.
+func NewScanner(r io.Reader, tbl *Table) *Scanner {
+	return &Scanner{r: bufio.NewReaderSize(r, scanChunkSize), tbl: tbl}
+}
+
+// Scan advances the Scanner to the next reference, which is then available through Ref.
+// It returns false when the input is exhausted or a read error occurs.
+func (s *Scanner) Scan() bool {
+	for len(s.pending) == 0 {
+		if s.done {
+			s.ref = nil
+			return false
+		}
+		chunk, err := s.r.ReadSlice('\n')
+		text := s.carry + string(chunk)
+		more := errors.Is(err, bufio.ErrBufferFull)
+		if err != nil && !more {
+			s.done = true
+			if !errors.Is(err, io.EOF) {
+				s.err = err
+			}
+		}
+		s.pending = s.scanText(text, more)
+	}
+
+	s.ref, s.pending = s.pending[0], s.pending[1:]
+	return true
+}
+
+// scanText parses the references in text. When more of the line is still to be read, only the
+// references that end before the last scanOverlap bytes are parsed, and the rest of text,
+// starting at a word or at the first reference not yet complete, is kept for the next read.
+func (s *Scanner) scanText(text string, more bool) []*BibleRef {
+	s.carry = ""
+	spans := FindRefSpans(text, s.tbl)
+	if more {
+		cut := max(len(text)-scanOverlap, 0)
+		for back := cut; back > 0 && cut-back < scanWordLimit; back-- {
+			if unicode.IsSpace(rune(text[back-1])) {
+				cut = back
+				break
+			}
+		}
+		if held := slices.IndexFunc(spans, func(sp RefSpan) bool { return sp.End > cut }); held >= 0 {
+			// A reference starting more than scanOverlap bytes back is too long to parse.
+			if spans[held].Start >= cut-scanOverlap {
+				cut = min(cut, spans[held].Start)
+			}
+			spans = spans[:held]
+		}
+		s.carry = text[cut:]
+	}
+
+	var refs []*BibleRef
+	for _, span := range spans {
+		if ref, err := Parse(text[span.Start:span.End], s.tbl); err == nil {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// Ref returns the reference found by the most recent call to Scan.
+func (s *Scanner) Ref() *BibleRef {
+	return s.ref
+}
+
+// Err returns the first read error encountered by the Scanner, or nil at the end of the input.
+func (s *Scanner) Err() error {
+	return s.err
+}
@@ -0,0 +1,112 @@
+package bibleref_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/julianstephens/canonref/bibleref"
+)
+
+// chunkReader returns its input a few bytes per Read, so references are split across reads.
+type chunkReader struct {
+	s    string
+	size int
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	if r.s == "" {
+		return 0, io.EOF
+	}
+	n := copy(p[:min(len(p), r.size)], r.s)
+	r.s = r.s[n:]
+	return n, nil
+}
+
+// TestScanner tests extracting references from multi-line input delivered in awkward reads.
+func TestScanner(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	text := "Opening: see Gen 1:1 and Prov 3:5-6.\n\nNo references here.\n(cf. Matt 5), then 1 Sam 3:4\nGen 51:1 is not a chapter; Jude 4"
+	expected := []string{"Gen 1:1", "Prov 3:5–6", "Matt 5", "1Sam 3:4", "Jude 1:4"}
+
+	readers := []struct {
+		r    io.Reader
+		desc string
+	}{
+		{strings.NewReader(text), "whole input"},
+		{iotest.OneByteReader(strings.NewReader(text)), "one byte per read"},
+		{&chunkReader{s: text, size: 7}, "seven bytes per read"},
+	}
+
+	for _, tc := range readers {
+		t.Run(tc.desc, func(t *testing.T) {
+			sc := bibleref.NewScanner(tc.r, tbl)
+			var got []string
+			for sc.Scan() {
+				got = append(got, sc.Ref().String())
+			}
+			if err := sc.Err(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if strings.Join(got, "; ") != strings.Join(expected, "; ") {
+				t.Errorf("expected %q, got %q", expected, got)
+			}
+			if sc.Scan() || sc.Ref() != nil {
+				t.Error("expected Scan to stay false at the end of the input")
+			}
+		})
+	}
+
+	readErr := errors.New("disk on fire")
+	sc := bibleref.NewScanner(io.MultiReader(strings.NewReader("Gen 1:1\nProv"), iotest.ErrReader(readErr)), tbl)
+	var got []string
+	for sc.Scan() {
+		got = append(got, sc.Ref().String())
+	}
+	if len(got) != 1 || !errors.Is(sc.Err(), readErr) {
+		t.Errorf("expected one reference and the read error, got %q and %v", got, sc.Err())
+	}
+}
+
+// TestScanner_LongLine tests that a long input without line breaks is read in chunks and that
+// references crossing a chunk boundary are still found.
+func TestScanner_LongLine(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	const n = 20000
+	text := strings.Repeat("text Prov 3:5-6 more 1 Sam 3:4, ", n)
+	for _, tc := range []struct {
+		r    io.Reader
+		desc string
+	}{
+		{strings.NewReader(text), "whole input"},
+		{&chunkReader{s: text, size: 1000}, "small reads"},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			sc := bibleref.NewScanner(tc.r, tbl)
+			count := 0
+			for sc.Scan() {
+				expected := []string{"Prov 3:5–6", "1Sam 3:4"}[count%2]
+				if got := sc.Ref().String(); got != expected {
+					t.Fatalf("reference %d: expected %q, got %q", count, expected, got)
+				}
+				count++
+			}
+			if err := sc.Err(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if count != 2*n {
+				t.Errorf("expected %d references, got %d", 2*n, count)
+			}
+		})
+	}
+}
@@ -1,7 +1,13 @@
 package bibleref
 
 import (
+	"cmp"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
 	"github.com/julianstephens/canonref/util"
 )
@@ -14,36 +20,295 @@ type booksWrapper struct {
 }
 
 // Table represents a mapping of OSIS codes to Books and aliases to OSIS codes.
+//
+// A Table is never modified by this package once NewTable returns, so any number of goroutines
+// may parse and look up references against it concurrently. Callers that share a Table must
+// not write to ByOsis or ByAlias; Freeze returns a copy that stays safe to share even if the
+// original's maps are later changed.
 type Table struct {
-	ByOsis  map[string]Book
+	// ByOsis maps OSIS codes to Books.
+	//
+	// Deprecated: Use Get or Book to look up a book by OSIS code. Direct access to the map will be
+	// removed in a future release.
+	ByOsis map[string]Book
+	// ByAlias maps normalized aliases and OSIS codes to OSIS codes.
+	//
+	// Deprecated: Use AliasToOSIS or Lookup. Direct access to the map will be removed in a
+	// future release.
 	ByAlias map[string]string
+
+	byOrder    map[int]string
+	foldedOSIS map[string]string
+	conflicts  []AliasConflict
+}
+
+// AliasConflict describes a normalized alias claimed by more than one book.
+// Winner is the OSIS code the alias resolves to and Loser the OSIS code that also claimed it.
+type AliasConflict struct {
+	Alias  string
+	Winner string
+	Loser  string
 }
 
 // NewTable creates a new Table from a slice of Books.
-// It validates each Book and returns an error if any Book is invalid.
+// It validates each Book and returns an error if any Book is invalid. Every invalid book is
+// reported, not just the first: the error joins one BibleRefError per bad book, naming its
+// index and OSIS code and wrapping the validation failure as its Cause, so
+// errors.Is(err, ErrInvalidBook) matches as before.
+//
+// Every book is reachable by its normalized OSIS code and its normalized aliases. When the
+// same key is claimed by more than one book, the resolution is deterministic regardless of
+// input order: a book's own OSIS code takes precedence over another book's alias, and
+// otherwise the book with the lowest Order wins. Each collision is recorded and reported by
+// AliasConflicts.
 func NewTable(books []Book) (*Table, error) {
 	tbl := &Table{
 		ByOsis:  make(map[string]Book, len(books)),
 		ByAlias: make(map[string]string, len(books)),
+		byOrder: make(map[int]string, len(books)),
 	}
 
-	for _, book := range books {
+	var errs []error
+	for i, book := range books {
 		if err := book.Validate(); err != nil {
-			return nil, err
+			errs = append(errs, &BibleRefError{
+				Kind:    KindInvalidBook,
+				Err:     ErrInvalidBook,
+				Message: util.Ptr(fmt.Sprintf("book %d (%q) is invalid", i, book.OSIS)),
+				Cause:   err,
+			})
 		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	tbl.foldedOSIS = make(map[string]string, len(books))
+	for _, book := range books {
 		tbl.ByOsis[book.OSIS] = book
+		tbl.byOrder[book.Order] = book.OSIS
+		folded := NormalizeAlias(book.OSIS)
+		if current, ok := tbl.foldedOSIS[folded]; !ok || book.Order < tbl.ByOsis[current].Order {
+			tbl.foldedOSIS[folded] = book.OSIS
+		}
+	}
+
+	for _, book := range books {
+		tbl.claimAlias(NormalizeAlias(book.OSIS), book)
+	}
+	for _, book := range books {
 		for _, alias := range book.Aliases {
 			normalizedAlias := NormalizeAlias(alias)
-			tbl.ByAlias[normalizedAlias] = book.OSIS
-		}
-		if !contains(tbl.ByAlias, NormalizeAlias(book.OSIS)) {
-			tbl.ByAlias[NormalizeAlias(book.OSIS)] = book.OSIS
+			if tbl.foldedOSIS[normalizedAlias] != "" && tbl.ByAlias[normalizedAlias] != book.OSIS {
+				tbl.conflicts = append(tbl.conflicts, AliasConflict{
+					Alias:  normalizedAlias,
+					Winner: tbl.ByAlias[normalizedAlias],
+					Loser:  book.OSIS,
+				})
+				continue
+			}
+			tbl.claimAlias(normalizedAlias, book)
 		}
 	}
 
 	return tbl, nil
 }
 
+// BookByOrder returns the Book with the given Order, e.g. 20 for Proverbs in the Protestant canon.
+// If several books share the same Order, the last one passed to NewTable is returned.
+// It returns false for a nil Table.
+func (t *Table) BookByOrder(order int) (Book, bool) {
+	if t == nil {
+		return Book{}, false
+	}
+	if t.byOrder == nil {
+		for _, book := range t.ByOsis {
+			if book.Order == order {
+				return book, true
+			}
+		}
+		return Book{}, false
+	}
+
+	osis, ok := t.byOrder[order]
+	if !ok {
+		return Book{}, false
+	}
+	return t.ByOsis[osis], true
+}
+
+// Book returns the Book with the given OSIS code. The code must match exactly; use Lookup to
+// resolve user input. It returns false for a nil Table.
+func (t *Table) Book(osis string) (Book, bool) {
+	if t == nil {
+		return Book{}, false
+	}
+	book, ok := t.ByOsis[osis]
+	return book, ok
+}
+
+// Get returns the Book with the given OSIS code, like Book. It is the accessor to use in place
+// of reading ByOsis directly.
+func (t *Table) Get(osis string) (Book, bool) {
+	return t.Book(osis)
+}
+
+// Lookup resolves a book name, alias, or OSIS code to its Book, the same way Parse resolves
+// the book part of a reference. The input is normalized with NormalizeAlias, so case,
+// periods, and roman numeral prefixes do not matter: "Proverbs", "prov.", and "II Sam" all
+// resolve. It returns false for a nil Table or an unknown book.
+func (t *Table) Lookup(s string) (Book, bool) {
+	if t == nil {
+		return Book{}, false
+	}
+	return resolveBook(t, NormalizeAlias(splitRomanPrefix(s)))
+}
+
+// AliasToOSIS returns the OSIS code that alias maps to, after normalizing it with
+// NormalizeAlias, so "Prov." and "proverbs" both give "Prov". Unlike Lookup it does not split
+// roman numeral prefixes or fold the case of OSIS codes missing from the alias map. It returns
+// false for a nil Table or an unknown alias.
+func (t *Table) AliasToOSIS(alias string) (string, bool) {
+	if t == nil {
+		return "", false
+	}
+	osis, ok := t.ByAlias[NormalizeAlias(alias)]
+	return osis, ok
+}
+
+// Freeze returns a deep copy of the Table that shares no maps or slices with t, including the
+// Aliases and VerseCounts of its books, for handing to goroutines that read it while t or its
+// books may still be written. Like any Table it is safe for concurrent reads as long as no
+// one writes to its ByOsis or ByAlias maps. It returns nil for a nil Table.
+func (t *Table) Freeze() *Table {
+	if t == nil {
+		return nil
+	}
+	byOsis := make(map[string]Book, len(t.ByOsis))
+	for osis, book := range t.ByOsis {
+		book.Aliases = slices.Clone(book.Aliases)
+		book.VerseCounts = slices.Clone(book.VerseCounts)
+		byOsis[osis] = book
+	}
+	return &Table{
+		ByOsis:     byOsis,
+		ByAlias:    maps.Clone(t.ByAlias),
+		byOrder:    maps.Clone(t.byOrder),
+		foldedOSIS: maps.Clone(t.foldedOSIS),
+		conflicts:  slices.Clone(t.conflicts),
+	}
+}
+
+// BooksInOrder returns the books of the Table sorted by Order, with books of equal Order sorted
+// by OSIS code so that the result is stable. It returns nil for a nil Table.
+func (t *Table) BooksInOrder() []Book {
+	if t == nil {
+		return nil
+	}
+	return slices.SortedFunc(maps.Values(t.ByOsis), func(a, b Book) int {
+		return cmp.Or(cmp.Compare(a.Order, b.Order), strings.Compare(a.OSIS, b.OSIS))
+	})
+}
+
+// BooksByTestament returns the books of the given testament, such as TestamentOld, in the
+// order of BooksInOrder. Any spelling accepted by ParseTestament may be passed, so "nt" and
+// "Apocrypha" also match. It returns nil for an unrecognized testament.
+func (t *Table) BooksByTestament(testament Testament) []Book {
+	want, ok := ParseTestament(string(testament))
+	if !ok {
+		return nil
+	}
+	var books []Book
+	for _, book := range t.BooksInOrder() {
+		if got, _ := ParseTestament(string(book.Testament)); got == want {
+			books = append(books, book)
+		}
+	}
+	return books
+}
+
+// MissingStandardBooks returns the OSIS codes of the 66 books of the Protestant canon that
+// are not in the Table, in canonical order. Spaces in the Table's OSIS codes are ignored, so a
+// dataset using "1 Sam" is treated as containing 1Sam. It returns an empty slice when the
+// Table has every standard book. A nil Table is missing every standard book.
+func (t *Table) MissingStandardBooks() []string {
+	if t == nil {
+		return slices.Clone(standardBooks)
+	}
+
+	present := make(map[string]bool, len(t.ByOsis))
+	for osis := range t.ByOsis {
+		present[strings.ReplaceAll(osis, " ", "")] = true
+	}
+
+	missing := []string{}
+	for _, osis := range standardBooks {
+		if !present[osis] {
+			missing = append(missing, osis)
+		}
+	}
+	return missing
+}
+
+// AliasConflicts returns the aliases that were claimed by more than one book when the
+// Table was built, in the order they were encountered. It returns nil for a nil Table.
+func (t *Table) AliasConflicts() []AliasConflict {
+	if t == nil {
+		return nil
+	}
+	return t.conflicts
+}
+
+// ShadowingAliases returns the aliases that are the leading words of a longer name or alias
+// of a different book, sorted and normalized. Such an alias is legal but surprising: with
+// "wisdom" as an alias of Sirach, "wisdom of solomon 2" reaches the Wisdom of Solomon while
+// "wisdom 2" routes to Sirach. Book names count as names here even when they are not listed
+// as aliases. It returns nil for a nil Table or when no alias shadows another book.
+func (t *Table) ShadowingAliases() []string {
+	if t == nil {
+		return nil
+	}
+
+	names := make(map[string]string, len(t.ByAlias)+len(t.ByOsis))
+	for alias, osis := range t.ByAlias {
+		names[alias] = osis
+	}
+	for osis, book := range t.ByOsis {
+		if name := NormalizeAlias(book.Name); names[name] == "" {
+			names[name] = osis
+		}
+	}
+
+	var shadowing []string
+	for alias, osis := range t.ByAlias {
+		for name, other := range names {
+			if other != osis && strings.HasPrefix(name, alias+" ") {
+				shadowing = append(shadowing, alias)
+				break
+			}
+		}
+	}
+	slices.Sort(shadowing)
+	return shadowing
+}
+
+// claimAlias assigns alias to book unless it already belongs to a book with a lower Order,
+// recording a conflict when another book already holds it.
+func (t *Table) claimAlias(alias string, book Book) {
+	current, exists := t.ByAlias[alias]
+	if !exists || current == book.OSIS {
+		t.ByAlias[alias] = book.OSIS
+		return
+	}
+
+	winner, loser := current, book.OSIS
+	if book.Order < t.ByOsis[current].Order {
+		winner, loser = book.OSIS, current
+		t.ByAlias[alias] = book.OSIS
+	}
+	t.conflicts = append(t.conflicts, AliasConflict{Alias: alias, Winner: winner, Loser: loser})
+}
+
 // LoadTableFromJSON loads a Table from JSON data.
 // The JSON should have schema, work, and books fields with an array of Book objects.
 func LoadTableFromJSON(jsonData []byte) (*Table, error) {
@@ -59,8 +324,3 @@ func LoadTableFromJSON(jsonData []byte) (*Table, error) {
 
 	return NewTable(wrapper.Books)
 }
-
-func contains(m map[string]string, key string) bool {
-	_, exists := m[key]
-	return exists
-}
@@ -0,0 +1,58 @@
+package bibleref
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/julianstephens/canonref/util"
+)
+
+// Testament identifies the part of the canon a Book belongs to. It is encoded in JSON as its
+// string value, e.g. "OT", exactly as it was given, so a table round-trips unchanged. A Book
+// may use any spelling accepted by ParseTestament; use ParseTestament to map it to a constant.
+type Testament string
+
+const (
+	TestamentOld       Testament = "OT"
+	TestamentNew       Testament = "NT"
+	TestamentApocrypha Testament = "Apocrypha"
+)
+
+// testamentNames maps the accepted spellings of each Testament, in lower case, to the Testament.
+var testamentNames = map[string]Testament{
+	"ot":        TestamentOld,
+	"nt":        TestamentNew,
+	"ap":        TestamentApocrypha,
+	"apocrypha": TestamentApocrypha,
+}
+
+// ParseTestament returns the Testament named by s, matched case-insensitively, so "ot", "NT",
+// and "Apocrypha" are all accepted. It returns false for an unrecognized name.
+func ParseTestament(s string) (Testament, bool) {
+	t, ok := testamentNames[strings.ToLower(strings.TrimSpace(s))]
+	return t, ok
+}
+
+// Valid reports whether t is a spelling of one of the Testament constants accepted by
+// ParseTestament, such as "OT", "nt", or "AP".
+func (t Testament) Valid() bool {
+	_, ok := ParseTestament(string(t))
+	return ok
+}
+
+// UnmarshalJSON decodes a Testament from a JSON string, keeping the spelling as given so that
+// it is encoded again unchanged. Unrecognized names are kept too, so that Book.Validate can
+// report them along with the book they belong to.
+func (t *Testament) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return &BibleRefError{
+			Kind:    KindInvalidBook,
+			Err:     ErrInvalidBook,
+			Message: util.Ptr("testament must be a string"),
+			Cause:   err,
+		}
+	}
+	*t = Testament(s)
+	return nil
+}
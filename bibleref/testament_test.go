@@ -0,0 +1,118 @@
+package bibleref_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/julianstephens/canonref/bibleref"
+)
+
+// TestParseTestament tests case-insensitive parsing of the accepted testament names.
+func TestParseTestament(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected bibleref.Testament
+		ok       bool
+	}{
+		{"OT", bibleref.TestamentOld, true},
+		{"nt", bibleref.TestamentNew, true},
+		{"AP", bibleref.TestamentApocrypha, true},
+		{"Apocrypha", bibleref.TestamentApocrypha, true},
+		{"Old", "", false},
+		{"", "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			got, ok := bibleref.ParseTestament(tc.input)
+			if got != tc.expected || ok != tc.ok {
+				t.Errorf("expected %q, %v, got %q, %v", tc.expected, tc.ok, got, ok)
+			}
+		})
+	}
+}
+
+// TestTestament_Valid tests that every spelling accepted by ParseTestament is valid.
+func TestTestament_Valid(t *testing.T) {
+	testCases := []struct {
+		testament bibleref.Testament
+		expected  bool
+	}{
+		{bibleref.TestamentOld, true},
+		{bibleref.TestamentNew, true},
+		{bibleref.TestamentApocrypha, true},
+		{"ot", true},
+		{"AP", true},
+		{"apocrypha", true},
+		{"OTT", false},
+		{"", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.testament), func(t *testing.T) {
+			if got := tc.testament.Valid(); got != tc.expected {
+				t.Errorf("Valid() = %v, expected %v", got, tc.expected)
+			}
+		})
+	}
+
+	if _, err := bibleref.NewTable([]bibleref.Book{{OSIS: "Bel", Name: "Bel and the Dragon", Testament: "Apocrypha", Order: 80, Chapters: 1}}); err != nil {
+		t.Errorf("expected the existing spelling \"Apocrypha\" to be accepted, got %v", err)
+	}
+}
+
+// TestLoadTableFromJSON_Testament tests that testaments keep their spelling through a load and
+// save round trip, and that unrecognized testaments are rejected.
+func TestLoadTableFromJSON_Testament(t *testing.T) {
+	testCases := []struct {
+		testament string
+		expected  bibleref.Testament
+		wantErr   bool
+	}{
+		{"OT", bibleref.TestamentOld, false},
+		{"nt", bibleref.TestamentNew, false},
+		{"AP", bibleref.TestamentApocrypha, false},
+		{"Apocrypha", bibleref.TestamentApocrypha, false},
+		{"OTT", "", true},
+		{"", "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testament, func(t *testing.T) {
+			data := []byte(`{"schema": 1, "work": "test", "books": [{"osis": "Ruth", "name": "Ruth", "testament": "` + tc.testament + `", "order": 8, "chapters": 4}]}`)
+			tbl, err := bibleref.LoadTableFromJSON(data)
+			if tc.wantErr {
+				if !errors.Is(err, bibleref.ErrInvalidBook) {
+					t.Fatalf("expected ErrInvalidBook, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadTableFromJSON failed: %v", err)
+			}
+			book, _ := tbl.Book("Ruth")
+			if book.Testament != bibleref.Testament(tc.testament) {
+				t.Errorf("expected testament %q as given, got %q", tc.testament, book.Testament)
+			}
+			if got, _ := bibleref.ParseTestament(string(book.Testament)); got != tc.expected {
+				t.Errorf("expected testament to parse as %q, got %q", tc.expected, got)
+			}
+			out, err := json.Marshal(book.Testament)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			if expected := `"` + tc.testament + `"`; string(out) != expected {
+				t.Errorf("expected round trip to %s, got %s", expected, out)
+			}
+		})
+	}
+
+	out, err := json.Marshal(bibleref.Book{OSIS: "Ruth", Name: "Ruth", Testament: bibleref.TestamentOld, Order: 8, Chapters: 4})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if expected := `{"osis":"Ruth","name":"Ruth","aliases":null,"testament":"OT","order":8,"chapters":4}`; string(out) != expected {
+		t.Errorf("expected %s, got %s", expected, out)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/julianstephens/canonref/bibleref"
 	"github.com/julianstephens/canonref/util"
@@ -15,6 +16,10 @@ type Suite struct {
 		input    string
 		expected string
 	}
+	listInputs []struct {
+		input    string
+		expected string
+	}
 }
 
 func NewSuite(inputs []struct {
@@ -43,6 +48,32 @@ func (s *Suite) TestParse() error {
 	return nil
 }
 
+func (s *Suite) TestParseList() error {
+	for _, test := range s.listInputs {
+		if err := s.runParseListTest(test.input, test.expected); err != nil {
+			return fmt.Errorf("list test failed for input '%s': %v", test.input, err)
+		}
+	}
+	return nil
+}
+
+func (s *Suite) runParseListTest(input, expected string) error {
+	refs, err := bibleref.ParseList(input, s.tbl)
+	if err != nil {
+		return err
+	}
+
+	formatted := make([]string, len(refs))
+	for i, ref := range refs {
+		formatted[i] = ref.Format(bibleref.FormatCanonical, nil)
+	}
+	if got := strings.Join(formatted, "; "); got != expected {
+		return fmt.Errorf("expected '%s', got '%s'", expected, got)
+	}
+
+	return nil
+}
+
 func (s *Suite) runParseTest(input, expected string) error {
 	ref, err := bibleref.Parse(input, s.tbl)
 	if err != nil {
@@ -70,6 +101,15 @@ func main() {
 		{"  Col   4 ", "Col 4"},
 	}
 
+	listInputs := []struct {
+		input    string
+		expected string
+	}{
+		{"Bel and the Dragon 3; Sus 4", "Bel 1:3; Sus 1:4"},
+		{"Sus 4 and Bel and the Dragon 3", "Sus 1:4; Bel 1:3"},
+		{"Genesis 1:1 and Exodus 2", "Gen 1:1; Exod 2"},
+	}
+
 	bookPath := flag.String("bookPath", "./books.json", "The path to the generated books.json to build the table from")
 	flag.Parse()
 
@@ -83,6 +123,12 @@ func main() {
 		println("Test failed during parsing:", err.Error())
 		return
 	}
+	s.listInputs = listInputs
+
+	if err := s.TestParseList(); err != nil {
+		println("Test failed during list parsing:", err.Error())
+		return
+	}
 
 	println("All tests passed!")
 }
@@ -0,0 +1,61 @@
+package bibleref
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/julianstephens/canonref/util"
+)
+
+// ParsePath parses a reference written as slash-separated URL path segments: a book, a
+// chapter, and optionally a verse or verse range, as in "prov/31/10-31" or "/Prov/31/". Each
+// segment is percent-decoded, and leading, trailing, and doubled slashes are ignored. The
+// segments are then parsed like "prov 31:10-31" by Parse with the given options.
+func ParsePath(path string, tbl *Table, opts ...ParseOption) (*BibleRef, error) {
+	var segs []string
+	for _, seg := range strings.Split(path, "/") {
+		if seg == "" {
+			continue
+		}
+		decoded, err := url.PathUnescape(seg)
+		if err != nil {
+			return nil, &BibleRefError{
+				Kind:    KindParse,
+				Err:     ErrBibleRefParseFailed,
+				Message: util.Ptr(fmt.Sprintf("invalid escape in path segment: %s", seg)),
+				Cause:   err,
+			}
+		}
+		segs = append(segs, decoded)
+	}
+	if len(segs) < 2 || len(segs) > 3 {
+		return nil, &BibleRefError{
+			Kind:    KindParse,
+			Err:     ErrBibleRefParseFailed,
+			Message: util.Ptr(fmt.Sprintf("path must have a book, a chapter, and an optional verse, got: %s", path)),
+		}
+	}
+
+	ref := segs[0] + " " + segs[1]
+	if len(segs) == 3 {
+		ref += ":" + segs[2]
+	}
+	return Parse(ref, tbl, opts...)
+}
+
+// ParseQuery parses a reference taken from a URL query value, such as "Prov%2031%3A10-31" or
+// "Prov+31%3A10-31". The value is decoded as a query component, so "+" is a space, and the
+// result is parsed by Parse with the given options.
+func ParseQuery(s string, tbl *Table, opts ...ParseOption) (*BibleRef, error) {
+	decoded, err := url.QueryUnescape(s)
+	if err != nil {
+		return nil, &BibleRefError{
+			Kind:    KindParse,
+			Err:     ErrBibleRefParseFailed,
+			Message: util.Ptr(fmt.Sprintf("invalid escape in query value: %s", s)),
+			Cause:   err,
+		}
+	}
+	return Parse(decoded, tbl, opts...)
+}
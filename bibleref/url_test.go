@@ -0,0 +1,96 @@
+package bibleref_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/julianstephens/canonref/bibleref"
+)
+
+// TestParsePath tests parsing slash-separated path segments, including encoded segments and
+// malformed paths.
+func TestParsePath(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected string
+		desc     string
+	}{
+		{"prov/31/10-31", "Prov 31:10–31", "book, chapter, and range"},
+		{"/Prov/31/", "Prov 31", "chapter with surrounding slashes"},
+		{"matt//5/3", "Matt 5:3", "doubled slash"},
+		{"1%20samuel/3/1", "1Sam 3:1", "encoded space in book"},
+		{"prov/31/10%E2%80%9331", "Prov 31:10–31", "encoded en dash"},
+		{"prov", "", "book only"},
+		{"prov/31/10/12", "", "too many segments"},
+		{"prov/31/%zz", "", "invalid escape"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ref, err := bibleref.ParsePath(tc.input, tbl)
+			if tc.expected == "" {
+				if !errors.Is(err, bibleref.ErrBibleRefParseFailed) {
+					t.Errorf("expected ErrBibleRefParseFailed for %q, got %v, %v", tc.input, ref.SafeString(), err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePath(%q) failed: %v", tc.input, err)
+			}
+			if ref.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, ref.String())
+			}
+		})
+	}
+}
+
+// TestParseQuery tests parsing percent-encoded and plus-encoded query values.
+func TestParseQuery(t *testing.T) {
+	tbl, err := bibleref.NewTable(testBooks())
+	if err != nil {
+		t.Fatalf("NewTable failed: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected string
+		desc     string
+	}{
+		{"Prov%2031%3A10-31", "Prov 31:10–31", "percent-encoded"},
+		{"Prov+31%3A10-31", "Prov 31:10–31", "plus for space"},
+		{"Matt 5:3", "Matt 5:3", "already decoded"},
+		{"Prov%2031%3", "", "truncated escape"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ref, err := bibleref.ParseQuery(tc.input, tbl)
+			if tc.expected == "" {
+				if !errors.Is(err, bibleref.ErrBibleRefParseFailed) {
+					t.Errorf("expected ErrBibleRefParseFailed for %q, got %v, %v", tc.input, ref.SafeString(), err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseQuery(%q) failed: %v", tc.input, err)
+			}
+			if ref.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, ref.String())
+			}
+		})
+	}
+
+	query := bibleref.MustParse("Prov 31:10-31", tbl)
+	encoded, err := query.FormatServiceURL(bibleref.ServiceBibleGateway)
+	if err != nil {
+		t.Fatalf("FormatServiceURL failed: %v", err)
+	}
+	if ref, err := bibleref.ParseQuery(encoded, tbl); err != nil || !ref.Equal(*query) {
+		t.Errorf("expected %q to round-trip to %v, got %v, %v", encoded, query, ref.SafeString(), err)
+	}
+}
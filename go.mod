@@ -1,5 +1,3 @@
 module github.com/julianstephens/canonref
 
 go 1.25.5
-
-require github.com/julianstephens/go-utils v0.4.3
@@ -1,6 +1,10 @@
 package rbref
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/julianstephens/canonref/util"
+)
 
 var (
 	ErrRbRefValidationFailed = fmt.Errorf("validation failed")
@@ -13,11 +17,10 @@ type RbRefError struct {
 	Cause   error
 }
 
+// Error formats the error as "rb reference error: <message>, err: <err> (cause: <cause>)",
+// omitting the message, err, and cause clauses that are unset.
 func (e *RbRefError) Error() string {
-	if e.Message != nil {
-		return fmt.Sprintf("rb reference error: %s, err: %v (cause: %v)", *e.Message, e.Err, e.Cause)
-	}
-	return fmt.Sprintf("rb reference error: err: %v (cause: %v)", e.Err, e.Cause)
+	return util.FormatError("rb reference error", e.Message, e.Err, e.Cause)
 }
 
 func (e *RbRefError) Unwrap() error {
@@ -5,8 +5,6 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/julianstephens/go-utils/helpers"
-
 	"github.com/julianstephens/canonref/util"
 )
 
@@ -23,12 +21,18 @@ type RbRef struct {
 	Verse      *util.VerseRange `json:"verse,omitempty"`
 }
 
+// Parse parses a Rule of St Benedict citation into an RbRef. Chapter references are written
+// "RB 7", "RB 7.31", or "RB 7.31–35", with the chapter between 1 and 73 and an optional line
+// or line range after the period. Prologue references are written "RB Prol. 45" or
+// "Prologue 45"; the "RB" prefix is optional for them since the keyword identifies the work.
+// Hyphens and em dashes in ranges are normalized to en dashes.
+func Parse(s string) (*RbRef, error) {
+	return parseRbRef(s)
+}
+
+// NewRbRef parses an RB reference string. It is equivalent to Parse.
 func NewRbRef(rbStr string) (*RbRef, error) {
-	ref, err := parseRbRef(rbStr)
-	if err != nil {
-		return nil, err
-	}
-	return ref, nil
+	return Parse(rbStr)
 }
 
 // validate checks that the RbRef has valid values based on its kind (prologue or chapter).
@@ -119,34 +123,40 @@ func (r *RbRef) String() string {
 	}
 }
 
-func parseRbRef(rbStr string) (*RbRef, error) {
-	var ref *RbRef
+// prologueKeywords lists the words, without a trailing period and in lower case, that
+// introduce a reference to the Prologue, as in "RB Prol. 45" or "Prologue 45".
+var prologueKeywords = map[string]bool{
+	"prol":     true,
+	"prologue": true,
+}
 
-	parts := strings.Split(rbStr, " ")
-	if len(parts) < 2 {
-		return nil, &RbRefError{
-			Err: ErrRbRefParseFailed,
-			Message: util.Ptr(fmt.Sprintf(
-				"invalid RB reference format: %s",
-				rbStr,
-			)),
-		}
+func isPrologueKeyword(s string) bool {
+	return prologueKeywords[strings.ToLower(strings.TrimSuffix(s, "."))]
+}
+
+func parseRbRef(rbStr string) (*RbRef, error) {
+	invalid := &RbRefError{
+		Err: ErrRbRefParseFailed,
+		Message: util.Ptr(fmt.Sprintf(
+			"invalid RB reference format: %s",
+			rbStr,
+		)),
 	}
 
-	work := parts[0]
-	if work != "RB" {
-		return nil, &RbRefError{
-			Err: ErrRbRefParseFailed,
-			Message: util.Ptr(fmt.Sprintf(
-				"invalid RB reference format: %s",
-				rbStr,
-			)),
-		}
+	parts := strings.Fields(util.NormalizeDigits(rbStr))
+	hasWork := len(parts) > 0 && parts[0] == "RB"
+	if hasWork {
+		parts = parts[1:]
+	}
+	if len(parts) == 0 {
+		return nil, invalid
 	}
 
-	// chapter reference format: "RB 2.1-5" or "RB 2.1"
-	if len(parts) == 2 {
-		if strings.HasPrefix(parts[1], "Prol.") || strings.HasPrefix(parts[1], "Prol") {
+	var ref *RbRef
+	switch {
+	// prologue reference format: "RB Prol. 1-5", "RB Prol 1", or "Prologue 45"
+	case isPrologueKeyword(parts[0]):
+		if len(parts) == 1 {
 			return nil, &RbRefError{
 				Err: ErrRbRefParseFailed,
 				Message: util.Ptr(fmt.Sprintf(
@@ -155,72 +165,51 @@ func parseRbRef(rbStr string) (*RbRef, error) {
 				)),
 			}
 		}
-		chapterRefParts := strings.Split(parts[1], ".")
-		if len(chapterRefParts) > 2 {
-			return nil, &RbRefError{
-				Err: ErrRbRefParseFailed,
-				Message: util.Ptr(fmt.Sprintf(
-					"invalid RB chapter reference format: %s",
-					rbStr,
-				)),
-			}
+		if len(parts) > 2 {
+			return nil, invalid
 		}
 
-		chapterNum, err := parsePositiveInt(chapterRefParts[0])
+		verseRange, err := parseVerseRange(parts[1])
 		if err != nil {
 			return nil, err
 		}
-
-		if len(chapterRefParts) == 1 {
-			ref = &RbRef{
-				Kind:       RbChapter,
-				ChapterNum: &chapterNum,
-				Verse:      nil,
-			}
-		} else {
-			verseRange, err := parseVerseRange(helpers.If(len(chapterRefParts) == 2, chapterRefParts[1], ""))
-			if err != nil {
-				return nil, err
-			}
-
-			ref = &RbRef{
-				Kind:       RbChapter,
-				ChapterNum: &chapterNum,
-				Verse:      verseRange,
-			}
+		ref = &RbRef{
+			Kind:  RbPrologue,
+			Verse: verseRange,
 		}
-	}
 
-	// prologue reference format: "RB Prol. 1-5" or "RB Prol. 1" or "RB Prol 1-5" or "RB Prol 1"
-	if len(parts) == 3 {
-		if parts[1] != "Prol." && parts[1] != "Prol" {
+	// chapter reference format: "RB 2.1-5", "RB 2.1", or "RB 2"
+	case hasWork && len(parts) == 1:
+		chapterRefParts := strings.Split(parts[0], ".")
+		if len(chapterRefParts) > 2 {
 			return nil, &RbRefError{
 				Err: ErrRbRefParseFailed,
 				Message: util.Ptr(fmt.Sprintf(
-					"invalid RB reference format: %s",
+					"invalid RB chapter reference format: %s",
 					rbStr,
 				)),
 			}
 		}
 
-		verseRange, err := parseVerseRange(parts[2])
+		chapterNum, err := parsePositiveInt(chapterRefParts[0])
 		if err != nil {
 			return nil, err
 		}
+
 		ref = &RbRef{
-			Kind:  RbPrologue,
-			Verse: verseRange,
+			Kind:       RbChapter,
+			ChapterNum: &chapterNum,
 		}
-	}
-
-	if ref == nil {
-		return nil, &RbRefError{
-			Err: ErrRbRefParseFailed,
-			Message: util.Ptr(fmt.Sprintf(
-				"invalid RB reference format: %s",
-				rbStr,
-			)),
+		if len(chapterRefParts) == 2 {
+			verseRange, err := parseVerseRange(chapterRefParts[1])
+			if err != nil {
+				return nil, err
+			}
+			ref.Verse = verseRange
 		}
+
+	default:
+		return nil, invalid
 	}
 
 	if err := ref.validate(); err != nil {
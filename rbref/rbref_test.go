@@ -18,6 +18,7 @@ func TestNewRbRef_ValidCases(t *testing.T) {
 		"RB 4.72-74", // hyphen accepted and normalized to en-dash
 		"RB 48.1—9",  // em-dash accepted and normalized to en-dash
 		"RB 4.72–74", // en-dash accepted
+		"RB ４８.１–９",  // full-width digits folded to ASCII
 	}
 
 	for _, tc := range ok {
@@ -201,3 +202,68 @@ func TestRbRef_ChapterReferences(t *testing.T) {
 		})
 	}
 }
+
+func TestParse_CanonicalRendering(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"RB 7.31–35", "RB 7.31–35"},
+		{"RB 7.31-35", "RB 7.31–35"},
+		{"RB 73", "RB 73"},
+		{"Prologue 45", "RB Prol. 45"},
+		{"prologue 1-7", "RB Prol. 1–7"},
+		{"Prol. 45", "RB Prol. 45"},
+		{"RB Prologue 45", "RB Prol. 45"},
+		{"  RB   Prol.  45 ", "RB Prol. 45"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			ref, err := rbref.Parse(tc.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tc.input, err)
+			}
+			if ref.String() != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, ref.String())
+			}
+		})
+	}
+
+	bad := []string{
+		"Prologue",
+		"7.31",         // chapter needs the RB prefix
+		"RB 7.31 35",   // trailing token
+		"Prologue 3 4", // trailing token
+		"RB Prologue 0",
+		"Epilogue 3",
+	}
+	for _, tc := range bad {
+		t.Run("invalid "+tc, func(t *testing.T) {
+			if ref, err := rbref.Parse(tc); err == nil {
+				t.Errorf("Expected error for %q, got %v", tc, ref)
+			}
+		})
+	}
+}
+
+// TestRbRefError_Error tests the formatted message with and without a cause.
+func TestRbRefError_Error(t *testing.T) {
+	testCases := []struct {
+		err      *rbref.RbRefError
+		expected string
+		desc     string
+	}{
+		{&rbref.RbRefError{Err: rbref.ErrRbRefParseFailed, Message: util.Ptr("bad chapter")}, "rb reference error: bad chapter, err: parse failed", "without cause"},
+		{&rbref.RbRefError{Err: rbref.ErrRbRefParseFailed, Message: util.Ptr("bad chapter"), Cause: rbref.ErrRbRefValidationFailed}, "rb reference error: bad chapter, err: parse failed (cause: validation failed)", "with cause"},
+		{&rbref.RbRefError{Err: rbref.ErrRbRefValidationFailed}, "rb reference error: err: validation failed", "no message"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.err.Error(); got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
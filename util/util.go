@@ -3,9 +3,10 @@ package util
 import (
 	"fmt"
 	"strconv"
+	"strings"
 )
 
-const EnDash = "–"
+const EnDash = "\u2013"
 const Hyphen = "-"
 
 func Ptr[T any](v T) *T {
@@ -19,14 +20,194 @@ func If[T any](cond bool, t, f T) T {
 	return f
 }
 
+// FormatError renders the error types of this module as "<prefix>: <message>, err: <err>
+// (cause: <cause>)", leaving out each of the message, err, and cause clauses that is unset.
+func FormatError(prefix string, message *string, err, cause error) string {
+	var parts []string
+	if message != nil {
+		parts = append(parts, *message)
+	}
+	if err != nil {
+		parts = append(parts, fmt.Sprintf("err: %v", err))
+	}
+	res := prefix
+	if len(parts) > 0 {
+		res += ": " + strings.Join(parts, ", ")
+	}
+	if cause != nil {
+		res += fmt.Sprintf(" (cause: %v)", cause)
+	}
+	return res
+}
+
+// VerseRange is a verse or an inclusive range of verses. StartPart and EndPart optionally
+// name the part of the start and end verse cited, a single letter from "a" to "d" as in
+// "23a" or "5b"; see IsVersePart.
+//
+// Following records the commentary notation for a verse and those after it: FollowingVerse
+// ("f") for the verse and the next, with EndVerse one past StartVerse, and FollowingVerses
+// ("ff") for the verse and the rest of the passage, with EndVerse set to the last verse of
+// the chapter when it is known and nil otherwise; such an open-ended range runs to the end of
+// the chapter (see IsOpenEnded). Both render in that notation, as "28f" or "28ff".
 type VerseRange struct {
-	StartVerse int  `json:"start"`
-	EndVerse   *int `json:"end,omitempty"`
+	StartVerse int    `json:"start"`
+	EndVerse   *int   `json:"end,omitempty"`
+	StartPart  string `json:"start_part,omitempty"`
+	EndPart    string `json:"end_part,omitempty"`
+	Following  string `json:"following,omitempty"`
 }
 
+// Values of VerseRange.Following.
+const (
+	FollowingVerse  = "f"
+	FollowingVerses = "ff"
+)
+
 func (v VerseRange) String() string {
+	if v.Following != "" {
+		return strconv.Itoa(v.StartVerse) + v.StartPart + v.Following
+	}
 	if v.EndVerse == nil {
-		return strconv.Itoa(v.StartVerse)
+		return strconv.Itoa(v.StartVerse) + v.StartPart
+	}
+	return fmt.Sprintf("%d%s%s%d%s", v.StartVerse, v.StartPart, EnDash, *v.EndVerse, v.EndPart)
+}
+
+// IsVersePart reports whether s is a valid verse part: a single lowercase letter from "a" to "d".
+func IsVersePart(s string) bool {
+	return len(s) == 1 && s[0] >= 'a' && s[0] <= 'd'
+}
+
+// Equal reports whether v and other cover the same verses, verse parts, and Following
+// notation, comparing EndVerse by value.
+func (v VerseRange) Equal(other VerseRange) bool {
+	if v.StartVerse != other.StartVerse || v.StartPart != other.StartPart || v.EndPart != other.EndPart || v.Following != other.Following {
+		return false
+	}
+	if v.EndVerse == nil || other.EndVerse == nil {
+		return v.EndVerse == nil && other.EndVerse == nil
+	}
+	return *v.EndVerse == *other.EndVerse
+}
+
+// Clone returns a copy of v that does not share its EndVerse pointer.
+func (v VerseRange) Clone() VerseRange {
+	if v.EndVerse != nil {
+		v.EndVerse = Ptr(*v.EndVerse)
+	}
+	return v
+}
+
+// IsOpenEnded reports whether v is a FollowingVerses ("ff") range whose last verse is not
+// known, so that it runs from StartVerse to the end of the chapter.
+func (v VerseRange) IsOpenEnded() bool {
+	return v.Following == FollowingVerses && v.EndVerse == nil
+}
+
+// Count returns the number of verses v covers: 1 for a single verse, and otherwise the
+// verses from StartVerse to EndVerse inclusive. A reversed range is counted as if normalized.
+// A verse cited only in part counts as a whole verse. It returns false for an open-ended
+// range (see IsOpenEnded), whose size depends on the chapter's verse count.
+func (v VerseRange) Count() (int, bool) {
+	if v.IsOpenEnded() {
+		return 0, false
+	}
+	n := v.Normalize()
+	if n.EndVerse == nil {
+		return 1, true
 	}
-	return fmt.Sprintf("%d%s%d", v.StartVerse, EnDash, *v.EndVerse)
+	return *n.EndVerse - n.StartVerse + 1, true
+}
+
+// Contains reports whether verse lies within v, inclusive of both ends. A reversed range is
+// treated as if normalized, and an open-ended range contains every verse from StartVerse on.
+func (v VerseRange) Contains(verse int) bool {
+	n := v.Normalize()
+	if n.IsOpenEnded() {
+		return verse >= n.StartVerse
+	}
+	if n.EndVerse == nil {
+		return verse == n.StartVerse
+	}
+	return verse >= n.StartVerse && verse <= *n.EndVerse
+}
+
+// Normalize returns a copy of v with StartVerse and EndVerse, and their parts, swapped if
+// EndVerse is before StartVerse, so "8–5" becomes "5–8". Other ranges are returned unchanged.
+func (v VerseRange) Normalize() VerseRange {
+	v = v.Clone()
+	if v.EndVerse != nil && *v.EndVerse < v.StartVerse {
+		v.StartVerse, *v.EndVerse = *v.EndVerse, v.StartVerse
+		v.StartPart, v.EndPart = v.EndPart, v.StartPart
+	}
+	return v
+}
+
+// Validate checks that StartVerse is a positive integer and that EndVerse, when set,
+// is not before StartVerse. Verse parts must be valid according to IsVersePart, EndPart
+// requires EndVerse, and a range within one verse must not end in an earlier part.
+// Following must be empty, FollowingVerse with EndVerse one past StartVerse, or
+// FollowingVerses.
+func (v VerseRange) Validate() error {
+	if v.StartVerse < 1 {
+		return fmt.Errorf("start verse must be a positive integer, got %d", v.StartVerse)
+	}
+	if v.EndVerse != nil && *v.EndVerse < v.StartVerse {
+		return fmt.Errorf("end verse must be greater than or equal to start verse, got start: %d, end: %d", v.StartVerse, *v.EndVerse)
+	}
+	if v.StartPart != "" && !IsVersePart(v.StartPart) {
+		return fmt.Errorf("start verse part must be a letter from a to d, got %q", v.StartPart)
+	}
+	if v.EndPart != "" && !IsVersePart(v.EndPart) {
+		return fmt.Errorf("end verse part must be a letter from a to d, got %q", v.EndPart)
+	}
+	if v.EndPart != "" && v.EndVerse == nil {
+		return fmt.Errorf("end verse part %q requires an end verse", v.EndPart)
+	}
+	if v.EndVerse != nil && *v.EndVerse == v.StartVerse && v.StartPart != "" && v.EndPart != "" && v.EndPart < v.StartPart {
+		return fmt.Errorf("end verse part must not come before start verse part, got %d%s%s%d%s", v.StartVerse, v.StartPart, EnDash, *v.EndVerse, v.EndPart)
+	}
+	if err := v.validateFollowing(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// validateFollowing checks that Following is a known notation consistent with EndVerse.
+func (v VerseRange) validateFollowing() error {
+	switch v.Following {
+	case "", FollowingVerses:
+		return nil
+	case FollowingVerse:
+		if v.EndVerse == nil || *v.EndVerse != v.StartVerse+1 {
+			return fmt.Errorf("%q range must end at verse %d", FollowingVerse, v.StartVerse+1)
+		}
+		return nil
+	}
+	return fmt.Errorf("following notation must be %q or %q, got %q", FollowingVerse, FollowingVerses, v.Following)
+}
+
+// superscriptDigits maps superscript digits, which are not contiguous in Unicode, to ASCII.
+var superscriptDigits = map[rune]rune{
+	'⁰': '0', '¹': '1', '²': '2', '³': '3', '⁴': '4',
+	'⁵': '5', '⁶': '6', '⁷': '7', '⁸': '8', '⁹': '9',
+}
+
+// NormalizeDigits folds full-width, superscript, Arabic-Indic, and Extended Arabic-Indic
+// digits in s to their ASCII equivalents. Other characters are left unchanged.
+func NormalizeDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= '０' && r <= '９':
+			return '0' + r - '０'
+		case r >= '٠' && r <= '٩':
+			return '0' + r - '٠'
+		case r >= '۰' && r <= '۹':
+			return '0' + r - '۰'
+		}
+		if d, ok := superscriptDigits[r]; ok {
+			return d
+		}
+		return r
+	}, s)
 }
@@ -0,0 +1,224 @@
+package util_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/julianstephens/canonref/util"
+)
+
+// TestNormalizeDigits tests that each supported digit family folds to ASCII.
+func TestNormalizeDigits(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+		desc     string
+	}{
+		{"3:16", "3:16", "ASCII unchanged"},
+		{"３:１６", "3:16", "full-width"},
+		{"⁰¹²³⁴⁵⁶⁷⁸⁹", "0123456789", "superscript"},
+		{"٠١٢٣٤٥٦٧٨٩", "0123456789", "Arabic-Indic"},
+		{"۰۱۲۳۴۵۶۷۸۹", "0123456789", "Extended Arabic-Indic"},
+		{"Prov ٣:٥–٨", "Prov 3:5–8", "non-digits preserved"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := util.NormalizeDigits(tc.input); got != tc.expected {
+				t.Errorf("NormalizeDigits(%q) = %q, expected %q", tc.input, got, tc.expected)
+			}
+		})
+	}
+}
+
+// TestVerseRange_Validate tests valid, zero-start, and reversed verse ranges.
+func TestVerseRange_Validate(t *testing.T) {
+	testCases := []struct {
+		v           util.VerseRange
+		expectError bool
+		desc        string
+	}{
+		{util.VerseRange{StartVerse: 5}, false, "single verse"},
+		{util.VerseRange{StartVerse: 5, EndVerse: util.Ptr(8)}, false, "range"},
+		{util.VerseRange{StartVerse: 5, EndVerse: util.Ptr(5)}, false, "range of one verse"},
+		{util.VerseRange{StartVerse: 0}, true, "zero start"},
+		{util.VerseRange{StartVerse: 8, EndVerse: util.Ptr(5)}, true, "reversed range"},
+		{util.VerseRange{StartVerse: 5, StartPart: "a"}, false, "verse part"},
+		{util.VerseRange{StartVerse: 5, EndVerse: util.Ptr(5), StartPart: "a", EndPart: "d"}, false, "parts of one verse"},
+		{util.VerseRange{StartVerse: 5, StartPart: "e"}, true, "part beyond d"},
+		{util.VerseRange{StartVerse: 5, StartPart: "A"}, true, "uppercase part"},
+		{util.VerseRange{StartVerse: 5, EndPart: "b"}, true, "end part without end verse"},
+		{util.VerseRange{StartVerse: 5, EndVerse: util.Ptr(5), StartPart: "b", EndPart: "a"}, true, "parts out of order"},
+		{util.VerseRange{StartVerse: 5, EndVerse: util.Ptr(6), Following: util.FollowingVerse}, false, "following verse"},
+		{util.VerseRange{StartVerse: 5, Following: util.FollowingVerses}, false, "following verses"},
+		{util.VerseRange{StartVerse: 5, EndVerse: util.Ptr(7), Following: util.FollowingVerse}, true, "following verse too far"},
+		{util.VerseRange{StartVerse: 5, Following: "fff"}, true, "unknown following notation"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.v.Validate()
+			if tc.expectError && err == nil {
+				t.Errorf("expected error for %v", tc.v)
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("unexpected error for %v: %v", tc.v, err)
+			}
+		})
+	}
+}
+
+// TestVerseRange_Equal tests value equality of verse ranges with distinct EndVerse pointers.
+func TestVerseRange_Equal(t *testing.T) {
+	testCases := []struct {
+		a, b     util.VerseRange
+		expected bool
+		desc     string
+	}{
+		{util.VerseRange{StartVerse: 5, EndVerse: util.Ptr(8)}, util.VerseRange{StartVerse: 5, EndVerse: util.Ptr(8)}, true, "distinct pointers"},
+		{util.VerseRange{StartVerse: 5}, util.VerseRange{StartVerse: 5}, true, "single verses"},
+		{util.VerseRange{StartVerse: 5}, util.VerseRange{StartVerse: 5, EndVerse: util.Ptr(5)}, false, "single verse and range"},
+		{util.VerseRange{StartVerse: 5, EndVerse: util.Ptr(8)}, util.VerseRange{StartVerse: 5, EndVerse: util.Ptr(9)}, false, "different end"},
+		{util.VerseRange{StartVerse: 4, EndVerse: util.Ptr(8)}, util.VerseRange{StartVerse: 5, EndVerse: util.Ptr(8)}, false, "different start"},
+		{util.VerseRange{StartVerse: 5, StartPart: "a"}, util.VerseRange{StartVerse: 5, StartPart: "b"}, false, "different part"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.a.Equal(tc.b); got != tc.expected {
+				t.Errorf("%v.Equal(%v) = %v, expected %v", tc.a, tc.b, got, tc.expected)
+			}
+		})
+	}
+}
+
+// TestVerseRange_Clone tests that a clone is equal but does not share EndVerse.
+func TestVerseRange_Clone(t *testing.T) {
+	v := util.VerseRange{StartVerse: 5, EndVerse: util.Ptr(8)}
+	c := v.Clone()
+	if !c.Equal(v) {
+		t.Fatalf("expected clone %v to equal %v", c, v)
+	}
+	if c.EndVerse == v.EndVerse {
+		t.Fatal("expected clone to have its own EndVerse pointer")
+	}
+
+	*c.EndVerse = 10
+	if *v.EndVerse != 8 {
+		t.Errorf("expected original end verse 8 after modifying clone, got %d", *v.EndVerse)
+	}
+
+	if single := (util.VerseRange{StartVerse: 3}).Clone(); single.EndVerse != nil {
+		t.Errorf("expected nil EndVerse in clone of single verse, got %v", single)
+	}
+}
+
+// TestEnDash tests that EnDash is the en dash code point and is used when rendering ranges.
+func TestEnDash(t *testing.T) {
+	if util.EnDash != "\u2013" {
+		t.Errorf("expected EnDash to be U+2013, got %q", util.EnDash)
+	}
+
+	v := util.VerseRange{StartVerse: 10, EndVerse: util.Ptr(31)}
+	if got := v.String(); got != "10\u201331" {
+		t.Errorf("expected %q, got %q", "10\u201331", got)
+	}
+}
+
+// TestVerseRange_String tests rendering of verses and ranges with and without verse parts.
+func TestVerseRange_String(t *testing.T) {
+	testCases := []struct {
+		v        util.VerseRange
+		expected string
+	}{
+		{util.VerseRange{StartVerse: 5}, "5"},
+		{util.VerseRange{StartVerse: 5, EndVerse: util.Ptr(8)}, "5–8"},
+		{util.VerseRange{StartVerse: 23, StartPart: "a"}, "23a"},
+		{util.VerseRange{StartVerse: 3, EndVerse: util.Ptr(5), StartPart: "a", EndPart: "b"}, "3a–5b"},
+		{util.VerseRange{StartVerse: 28, EndVerse: util.Ptr(29), Following: util.FollowingVerse}, "28f"},
+		{util.VerseRange{StartVerse: 28, EndVerse: util.Ptr(39), Following: util.FollowingVerses}, "28ff"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			if got := tc.v.String(); got != tc.expected {
+				t.Errorf("String() = %q, expected %q", got, tc.expected)
+			}
+		})
+	}
+}
+
+// TestVerseRange_CountContainsNormalize tests the verse count, membership, and normalized
+// form of single verses, ranges, reversed ranges, and "ff" ranges with and without a known
+// end. A count of -1 marks a range that cannot be counted.
+func TestVerseRange_CountContainsNormalize(t *testing.T) {
+	testCases := []struct {
+		v          util.VerseRange
+		count      int
+		inside     []int
+		outside    []int
+		normalized string
+		desc       string
+	}{
+		{util.VerseRange{StartVerse: 5}, 1, []int{5}, []int{4, 6}, "5", "single verse"},
+		{util.VerseRange{StartVerse: 5, EndVerse: util.Ptr(8)}, 4, []int{5, 6, 8}, []int{4, 9}, "5–8", "range"},
+		{util.VerseRange{StartVerse: 5, EndVerse: util.Ptr(5)}, 1, []int{5}, []int{4, 6}, "5–5", "range of one verse"},
+		{util.VerseRange{StartVerse: 8, EndVerse: util.Ptr(5)}, 4, []int{5, 7, 8}, []int{4, 9}, "5–8", "reversed range"},
+		{util.VerseRange{StartVerse: 8, EndVerse: util.Ptr(5), StartPart: "a", EndPart: "b"}, 4, []int{5, 8}, []int{9}, "5b–8a", "reversed range with parts"},
+		{util.VerseRange{StartVerse: 28, EndVerse: util.Ptr(39), Following: util.FollowingVerses}, 12, []int{28, 29, 39}, []int{27, 40}, "28ff", "filled ff"},
+		{util.VerseRange{StartVerse: 28, Following: util.FollowingVerses}, -1, []int{28, 29, 100}, []int{27}, "28ff", "open-ended ff"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, ok := tc.v.Count()
+			if ok != (tc.count >= 0) || ok && got != tc.count {
+				t.Errorf("Count() = %d, %v, expected %d", got, ok, tc.count)
+			}
+			for _, verse := range tc.inside {
+				if !tc.v.Contains(verse) {
+					t.Errorf("expected %v to contain %d", tc.v, verse)
+				}
+			}
+			for _, verse := range tc.outside {
+				if tc.v.Contains(verse) {
+					t.Errorf("expected %v not to contain %d", tc.v, verse)
+				}
+			}
+			if got := tc.v.Normalize().String(); got != tc.normalized {
+				t.Errorf("Normalize() = %q, expected %q", got, tc.normalized)
+			}
+		})
+	}
+
+	reversed := util.VerseRange{StartVerse: 8, EndVerse: util.Ptr(5)}
+	reversed.Normalize()
+	if reversed.StartVerse != 8 || *reversed.EndVerse != 5 {
+		t.Errorf("expected Normalize to leave the receiver unchanged, got %v", reversed)
+	}
+}
+
+// TestFormatError tests that unset clauses are left out of formatted errors.
+func TestFormatError(t *testing.T) {
+	err, cause := errors.New("parse failed"), errors.New("bad digit")
+	testCases := []struct {
+		message  *string
+		err      error
+		cause    error
+		expected string
+	}{
+		{util.Ptr("bad ref"), err, cause, "x error: bad ref, err: parse failed (cause: bad digit)"},
+		{util.Ptr("bad ref"), err, nil, "x error: bad ref, err: parse failed"},
+		{nil, err, nil, "x error: err: parse failed"},
+		{util.Ptr("bad ref"), nil, nil, "x error: bad ref"},
+		{nil, nil, nil, "x error"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			if got := util.FormatError("x error", tc.message, tc.err, tc.cause); got != tc.expected {
+				t.Errorf("FormatError() = %q, expected %q", got, tc.expected)
+			}
+		})
+	}
+}